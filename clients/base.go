@@ -1,8 +1,13 @@
 package clients
 
 import (
+	"context"
 	"database/sql"
 	"errors"
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
 
 	"github.com/sharovik/orm/dto"
 	"github.com/sharovik/orm/query"
@@ -13,6 +18,11 @@ const (
 	AlterType           = "ALTER"
 	RenameType          = "RENAME"
 	DropType            = "DROP"
+	CreateViewType      = "CREATE_VIEW"
+	DropViewType        = "DROP_VIEW"
+	TruncateType        = "TRUNCATE"
+	CreateTriggerType   = "CREATE_TRIGGER"
+	DropTriggerType     = "DROP_TRIGGER"
 	SelectType          = "SELECT"
 	InsertType          = "INSERT"
 	UpdateType          = "UPDATE"
@@ -21,6 +31,11 @@ const (
 	TransactionCommit   = "TRANSACTION_COMMIT"
 	TransactionRollback = "TRANSACTION_ROLLBACK"
 
+	UnionCompound     = "UNION"
+	UnionAllCompound  = "UNION ALL"
+	IntersectCompound = "INTERSECT"
+	ExceptCompound    = "EXCEPT"
+
 	DatabaseTypeMySQL   = "mysql"
 	DatabaseTypeSqlite  = "sqlite"
 	DefaultDatabaseType = DatabaseTypeSqlite
@@ -65,14 +80,46 @@ type BaseClientInterface interface {
 	Connect(config DatabaseConfig) (client BaseClientInterface, err error)
 	Disconnect() error
 	GetClient() *sql.DB
+
+	//Session returns the client, which executes all queries using the same connection of the pool. It should be used for the temporary tables and the transactions, because they are available only for the connection where they were created.
+	//The Disconnect method of the session returns the connection to the pool
+	Session(ctx context.Context) (client BaseClientInterface, err error)
 	ToSql(query QueryInterface) string
 	Execute(query QueryInterface) (result dto.BaseResult, err error)
+	ExecuteContext(ctx context.Context, query QueryInterface) (result dto.BaseResult, err error)
+
+	//Page executes the select query for selected page and the COUNT(*) query for the total number of rows
+	Page(ctx context.Context, q QueryInterface, page int64, perPage int64) (result dto.PageResult, err error)
+
+	//Constraints returns the PRIMARY KEY, UNIQUE and CHECK constraints of the table
+	Constraints(ctx context.Context, table string) ([]dto.Constraint, error)
+
+	//Columns returns the columns of the table with their definitions in the order of the table
+	Columns(ctx context.Context, table string) ([]dto.ModelField, error)
 
+	//TableOptions returns the options of the table, eg: engine, charset, collation or comment
+	TableOptions(ctx context.Context, table string) (dto.TableOptions, error)
+
+	//Views returns the views of the database
+	Views(ctx context.Context) ([]dto.View, error)
+
+	//Triggers returns the triggers of the table
+	Triggers(ctx context.Context, table string) ([]dto.Trigger, error)
+
+	prepareSelectQuery(q QueryInterface) string
+	prepareUpdateQuery(q QueryInterface) string
+	prepareDeleteQuery(q QueryInterface) string
 	prepareCreateQuery(q QueryInterface) string
+	prepareCreateViewQuery(q QueryInterface) string
+	prepareTruncateQuery(q QueryInterface) string
+	prepareCreateTriggerQuery(q QueryInterface) string
 	prepareAlterQuery(q QueryInterface) string
+	prepareCountQuery(q QueryInterface) QueryInterface
 	prepareTransactionBegin() string
 	prepareTransactionCommit() string
 	prepareTransactionRollback() string
+	validateQuery(q QueryInterface) error
+	prepareBindings(q QueryInterface) []query.Bind
 }
 
 // QueryInterface the interface for the query builder of the client
@@ -83,6 +130,42 @@ type QueryInterface interface {
 	//Drop method will return the query object for table drop
 	Drop(dto.ModelInterface) QueryInterface
 
+	//CreateFromSelect method will return the query object for the table creation from the select query: CREATE TABLE name AS SELECT ...
+	CreateFromSelect(name string, q QueryInterface) QueryInterface
+
+	//Truncate method will return the query object, which removes all rows of the table
+	Truncate(dto.ModelInterface) QueryInterface
+
+	//CreateView method will return the query object for the view creation. The select query is used as the definition of the view
+	CreateView(name string, q QueryInterface) QueryInterface
+
+	//DropView method will return the query object for the view drop
+	DropView(name string) QueryInterface
+
+	//CreateTrigger method will return the query object for the trigger creation
+	CreateTrigger(trigger dto.Trigger) QueryInterface
+
+	//DropTrigger method will return the query object for the trigger drop
+	DropTrigger(name string) QueryInterface
+
+	//GetTrigger returns the trigger of the query
+	GetTrigger() dto.Trigger
+
+	//OrReplace sets the orReplace flag. Method can be used in the combination with CreateView method to replace the existing view
+	OrReplace() QueryInterface
+
+	//GetOrReplace returns the orReplace flag of the query
+	GetOrReplace() bool
+
+	//IfExists sets the ifExists flag. Method can be used in the combination with Drop, DropView or DropTrigger methods to have condition DROP TABLE IF EXISTS, DROP VIEW IF EXISTS or DROP TRIGGER IF EXISTS
+	IfExists() QueryInterface
+
+	//GetIfExists returns the ifExists flag of the query
+	GetIfExists() bool
+
+	//GetViewQuery returns the select query of the view
+	GetViewQuery() QueryInterface
+
 	//Select using that method you can set the attributes for selection. This method should be used from the beginning of your query, to specify the initial query string.
 	//This method returns the updated Query object.
 	Select(columns interface{}) QueryInterface
@@ -97,8 +180,37 @@ type QueryInterface interface {
 	//This method receives the dto.ModelInterface object and returns the updated QueryInterface object.
 	Update(dto.ModelInterface) QueryInterface
 
+	//Save method inserts the model, if its primary key is empty. Otherwise, the model will be updated by the primary key
+	Save(model dto.ModelInterface) QueryInterface
+
+	//DeleteModel method deletes the model by its primary key
+	DeleteModel(model dto.ModelInterface) QueryInterface
+
+	//Find method selects the model by the primary key. For the composite primary key the values should be in the order of the GetPrimaryKeys method result
+	Find(model dto.ModelInterface, primaryKey ...interface{}) QueryInterface
+
+	//GetPrimaryKeyValues returns the values of the primary key, which were specified by Find method
+	GetPrimaryKeyValues() []interface{}
+
+	//UpdateDirty method should be used when you need to update only the fields of the model, which were changed after the model was loaded from the database.
+	//The condition by the primary key is added automatically.
+	UpdateDirty(model dto.ModelInterface) QueryInterface
+
+	//UpdateTable method should be used when you need to update only the columns, which are specified by Set, Increment and Decrement methods.
+	//It receives the dto.ModelInterface object or the table name and returns the updated QueryInterface object.
+	UpdateTable(table interface{}) QueryInterface
+
+	//Set method adds the column assignment to the UPDATE query. The value can be the query.Expression, the nested select query or the value, which will be bound
+	Set(column string, value interface{}) QueryInterface
+
+	//Increment method increases the value of the column in the UPDATE query: column = column + value
+	Increment(column string, value interface{}) QueryInterface
+
+	//Decrement method decreases the value of the column in the UPDATE query: column = column - value
+	Decrement(column string, value interface{}) QueryInterface
+
 	//Alter method should be used when you need to alter selected table.
-	//It should be used from the beginning of your query, to specify the initial query string and with combination one of the methods AddColumn, DropColumn, AddIndex, DropIndex, AddForeignKey, DropForeignKey
+	//It should be used from the beginning of your query, to specify the initial query string and with combination one of the methods AddColumn, DropColumn, RenameColumn, ModifyColumn, AddIndex, DropIndex, AddForeignKey, DropForeignKey
 	//This method receives the dto.ModelInterface object and returns the updated QueryInterface object.
 	Alter(dto.ModelInterface) QueryInterface
 
@@ -112,24 +224,42 @@ type QueryInterface interface {
 	GetQueryType() string
 	GetNewTableName() string
 	GetDestination() dto.ModelInterface
+	GetFromQuery() QueryInterface
 	GetColumns() []interface{}
 	GetColumnsToDrop() []interface{}
+	GetColumnsToRename() []dto.ColumnRename
+	GetColumnsToModify() []dto.ModelField
 	GetForeignKeysToAdd() []dto.ForeignKey
 	GetForeignKeysToDrop() []dto.ForeignKey
+	GetConstraintsToAdd() []dto.Constraint
+	GetConstraintsToDrop() []dto.Constraint
 	GetIndexesToAdd() []dto.Index
 	GetIndexesToDrop() []dto.Index
 	GetWheres() []query.Where
 	GetJoins() []query.Join
+	GetJoinBindings() []query.Bind
 	GetOrderBy() []query.OrderByColumn
 	GetGroupBy() []string
+	GetHavings() []query.Where
 	GetLimit() query.Limit
+	GetDistinct() bool
+	GetCompounds() []Compound
+	GetWith() []CommonTableExpression
+	GetWindows() []query.Window
+	GetLock() query.Lock
 
 	Values(interface{}) QueryInterface
 	GetValues() interface{}
 
-	//From using this method you can specify the ORDER BY fields with the right direction to order.
+	//From using this method you can specify the table for the query. It can be the dto.ModelInterface, the table name or the SubQuery object
 	From(model interface{}) QueryInterface
 
+	//Temporary sets the temporary flag. Method can be used in the combination with Create or CreateFromSelect methods to have the CREATE TEMPORARY TABLE statement
+	Temporary() QueryInterface
+
+	//GetTemporary returns the temporary flag of the query
+	GetTemporary() bool
+
 	//IfNotExists Sets the IfNotExists flag. Method can be used in the combination with CREATE TABLE statement to have condition CREATE TABLE IF NOT EXISTS
 	IfNotExists() QueryInterface
 
@@ -139,14 +269,65 @@ type QueryInterface interface {
 	//OrderBy using this method you can specify the ORDER BY fields with the right direction to order.
 	OrderBy(field string, direction string) QueryInterface
 
+	//ResetOrderBy removes all ORDER BY fields of the query
+	ResetOrderBy() QueryInterface
+
+	//ResetColumns removes all selected columns of the query
+	ResetColumns() QueryInterface
+
+	//ResetLock removes the locking clause of the query
+	ResetLock() QueryInterface
+
+	//Clone returns the copy of the query, which can be modified without changes of the original query
+	Clone() QueryInterface
+
 	Limit(limit query.Limit) QueryInterface
 
 	//GroupBy using this method you can specify the fields for the GROUP BY clause.
 	GroupBy(field string) QueryInterface
 
+	//Distinct method can be used for the SELECT DISTINCT statement
+	Distinct() QueryInterface
+
+	//ForUpdate method adds the FOR UPDATE locking clause to the select query. The selected rows will be locked till the end of the transaction
+	ForUpdate() QueryInterface
+
+	//ForShare method adds the FOR SHARE locking clause to the select query. The selected rows can be read, but cannot be modified by other transactions
+	ForShare() QueryInterface
+
+	//NoWait method sets the NOWAIT option of the locking clause. The query fails immediately, if the rows are locked by another transaction
+	NoWait() QueryInterface
+
+	//SkipLocked method sets the SKIP LOCKED option of the locking clause. The rows, which are locked by another transaction, are skipped
+	SkipLocked() QueryInterface
+
+	//Window method adds the named window to the WINDOW clause. This window can be referenced by the name in the window functions
+	Window(name string, window query.Window) QueryInterface
+
+	//With method adds the common table expression to the query. The name can contain the list of columns, eg: tree(id, parent_id)
+	With(name string, q QueryInterface) QueryInterface
+
+	//WithRecursive method adds the recursive common table expression to the query
+	WithRecursive(name string, q QueryInterface) QueryInterface
+
+	//Union combines the results of the query with the results of selected query. The OrderBy and Limit of the current query will be applied to the combined result
+	Union(QueryInterface) QueryInterface
+
+	//UnionAll combines the results of the query with the results of selected query without removal of duplicates
+	UnionAll(QueryInterface) QueryInterface
+
+	//Intersect returns the rows which exist in the results of the query and selected query
+	Intersect(QueryInterface) QueryInterface
+
+	//Except returns the rows of the query which do not exist in the results of selected query
+	Except(QueryInterface) QueryInterface
+
 	//Where method needed for WHERE clause configuration.
 	Where(where query.Where) QueryInterface
 
+	//Having method needed for HAVING clause configuration. It should be used in the combination with GroupBy method.
+	Having(having query.Where) QueryInterface
+
 	//Join method can be used for specification of JOIN clause.
 	Join(join query.Join) QueryInterface
 
@@ -164,12 +345,24 @@ type QueryInterface interface {
 	//DropForeignKey the method which identifies which foreign key we need to drop for selected model in Alter method
 	DropForeignKey(field dto.ForeignKey) QueryInterface
 
+	//RenameColumn the method which identifies which column we need to rename for selected model in Alter method
+	RenameColumn(original string, newName string) QueryInterface
+
+	//ModifyColumn the method which identifies which column definition we need to change for selected model in Alter method. The column is identified by its name, so for the renamed column the new name should be used
+	ModifyColumn(column dto.ModelField) QueryInterface
+
 	//AddIndex the method which identifies which index key we need to add for selected model in Alter method
 	AddIndex(index dto.Index) QueryInterface
 
 	//DropIndex the method which identifies which index key we need to add for selected model in Alter method
 	DropIndex(index dto.Index) QueryInterface
 
+	//AddConstraint the method which identifies which table constraint we need to add for selected model in Create or Alter method
+	AddConstraint(constraint dto.Constraint) QueryInterface
+
+	//DropConstraint the method which identifies which table constraint we need to drop for selected model in Alter method
+	DropConstraint(constraint dto.Constraint) QueryInterface
+
 	//GetBindings method returns the binding collected during the query building
 	GetBindings() []query.Bind
 
@@ -183,25 +376,74 @@ type QueryInterface interface {
 	RollbackTransaction() QueryInterface
 }
 
+// executor the database connection, which executes the queries. It can be the pool of the connections or the connection pinned by the session
+type executor interface {
+	ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error)
+	QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error)
+	QueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row
+}
+
+// SubQuery the object which can be used for the derived table definition in the FROM clause
+type SubQuery struct {
+	Query QueryInterface
+	Alias string
+}
+
+// Compound the query which will be combined with the main query using the set operation. Eg: UNION, INTERSECT
+type Compound struct {
+	Type  string
+	Query QueryInterface
+}
+
+// CommonTableExpression the named query which will be defined in the WITH clause
+type CommonTableExpression struct {
+	Name      string
+	Query     QueryInterface
+	Recursive bool
+}
+
 // Query the query object of the SQLite client
 type Query struct {
-	destination     dto.ModelInterface
-	bindings        []query.Bind
-	queryType       string
-	newTableName    string
-	columns         []interface{}
-	columnsDrop     []interface{}
-	ifNotExists     bool
-	indexAdd        []dto.Index
-	indexDrop       []dto.Index
-	foreignKeysAdd  []dto.ForeignKey
-	foreignKeysDrop []dto.ForeignKey
-	wheres          []query.Where
-	joins           []query.Join
-	orderBys        []query.OrderByColumn
-	groupBys        []string
-	values          interface{}
-	limit           query.Limit
+	destination      dto.ModelInterface
+	fromQuery        QueryInterface
+	bindings         []query.Bind
+	withBindings     []query.Bind
+	fromBindings     []query.Bind
+	joinBindings     []query.Bind
+	whereBindings    []query.Bind
+	havingBindings   []query.Bind
+	compoundBindings []query.Bind
+	queryType        string
+	newTableName     string
+	columns          []interface{}
+	columnsDrop      []interface{}
+	columnsRename    []dto.ColumnRename
+	columnsModify    []dto.ModelField
+	ifNotExists      bool
+	temporary        bool
+	ifExists         bool
+	orReplace        bool
+	viewQuery        QueryInterface
+	trigger          dto.Trigger
+	indexAdd         []dto.Index
+	indexDrop        []dto.Index
+	foreignKeysAdd   []dto.ForeignKey
+	foreignKeysDrop  []dto.ForeignKey
+	constraintsAdd   []dto.Constraint
+	constraintsDrop  []dto.Constraint
+	wheres           []query.Where
+	joins            []query.Join
+	orderBys         []query.OrderByColumn
+	groupBys         []string
+	havings          []query.Where
+	distinct         bool
+	compounds        []Compound
+	with             []CommonTableExpression
+	windows          []query.Window
+	values           interface{}
+	limit            query.Limit
+	lock             query.Lock
+	primaryKeyValues []interface{}
 }
 
 func (q *Query) GetQueryType() string {
@@ -231,6 +473,10 @@ func (q *Query) GetDestination() dto.ModelInterface {
 	return q.destination
 }
 
+func (q *Query) GetFromQuery() QueryInterface {
+	return q.fromQuery
+}
+
 func (q *Query) GetColumns() []interface{} {
 	return q.columns
 }
@@ -239,6 +485,14 @@ func (q *Query) GetColumnsToDrop() []interface{} {
 	return q.columnsDrop
 }
 
+func (q *Query) GetColumnsToRename() []dto.ColumnRename {
+	return q.columnsRename
+}
+
+func (q *Query) GetColumnsToModify() []dto.ModelField {
+	return q.columnsModify
+}
+
 func (q *Query) GetForeignKeysToAdd() []dto.ForeignKey {
 	return q.foreignKeysAdd
 }
@@ -247,6 +501,14 @@ func (q *Query) GetForeignKeysToDrop() []dto.ForeignKey {
 	return q.foreignKeysDrop
 }
 
+func (q *Query) GetConstraintsToAdd() []dto.Constraint {
+	return q.constraintsAdd
+}
+
+func (q *Query) GetConstraintsToDrop() []dto.Constraint {
+	return q.constraintsDrop
+}
+
 func (q *Query) GetIndexesToAdd() []dto.Index {
 	return q.indexAdd
 }
@@ -263,6 +525,10 @@ func (q *Query) GetJoins() []query.Join {
 	return q.joins
 }
 
+func (q *Query) GetJoinBindings() []query.Bind {
+	return q.joinBindings
+}
+
 func (q *Query) GetOrderBy() []query.OrderByColumn {
 	return q.orderBys
 }
@@ -271,15 +537,57 @@ func (q *Query) GetGroupBy() []string {
 	return q.groupBys
 }
 
+func (q *Query) GetHavings() []query.Where {
+	return q.havings
+}
+
+// GetBindings returns the bindings in the same order as the placeholders appear in the generated query
 func (q *Query) GetBindings() []query.Bind {
-	return q.bindings
+	var bindings []query.Bind
+	bindings = append(bindings, q.withBindings...)
+
+	switch v := q.values.(type) {
+	case QueryInterface:
+		//For the INSERT ... SELECT statement the values are selected by the nested query
+		bindings = append(bindings, v.GetBindings()...)
+	default:
+		bindings = append(bindings, q.bindings...)
+	}
+
+	bindings = append(bindings, q.fromBindings...)
+	bindings = append(bindings, q.joinBindings...)
+	bindings = append(bindings, q.whereBindings...)
+	bindings = append(bindings, q.havingBindings...)
+	bindings = append(bindings, q.compoundBindings...)
+
+	return bindings
 }
 
 func (q *Query) GetLimit() query.Limit {
 	return q.limit
 }
 
-// From using this method you can specify the ORDER BY fields with the right direction to order.
+func (q *Query) GetDistinct() bool {
+	return q.distinct
+}
+
+func (q *Query) GetCompounds() []Compound {
+	return q.compounds
+}
+
+func (q *Query) GetWith() []CommonTableExpression {
+	return q.with
+}
+
+func (q *Query) GetWindows() []query.Window {
+	return q.windows
+}
+
+func (q *Query) GetLock() query.Lock {
+	return q.lock
+}
+
+// From using this method you can specify the table for the query. It can be the dto.ModelInterface, the table name or the SubQuery object
 func (q *Query) From(model interface{}) QueryInterface {
 	switch v := model.(type) {
 	case dto.ModelInterface:
@@ -288,11 +596,28 @@ func (q *Query) From(model interface{}) QueryInterface {
 		q.destination = &dto.BaseModel{
 			TableName: v,
 		}
+	case SubQuery:
+		q.destination = &dto.BaseModel{
+			TableName: v.Alias,
+		}
+		q.fromQuery = v.Query
+		q.fromBindings = append(q.fromBindings, v.Query.GetBindings()...)
 	}
 
 	return q
 }
 
+// Temporary sets the temporary flag. Method can be used in the combination with Create or CreateFromSelect methods to have the CREATE TEMPORARY TABLE statement
+func (q *Query) Temporary() QueryInterface {
+	q.temporary = true
+	return q
+}
+
+// GetTemporary returns the temporary flag of the query
+func (q *Query) GetTemporary() bool {
+	return q.temporary
+}
+
 // IfNotExists sets the ifNotExists flag. method can be used in the combination with CREATE TABLE statement to have condition CREATE TABLE IF NOT EXISTS
 func (q *Query) IfNotExists() QueryInterface {
 	q.ifNotExists = true
@@ -304,6 +629,38 @@ func (q *Query) GetIfNotExists() bool {
 	return q.ifNotExists
 }
 
+// OrReplace sets the orReplace flag. Method can be used in the combination with CreateView method to replace the existing view
+func (q *Query) OrReplace() QueryInterface {
+	q.orReplace = true
+	return q
+}
+
+// GetOrReplace returns the orReplace flag of the query
+func (q *Query) GetOrReplace() bool {
+	return q.orReplace
+}
+
+// IfExists sets the ifExists flag. Method can be used in the combination with Drop, DropView or DropTrigger methods to have condition DROP TABLE IF EXISTS, DROP VIEW IF EXISTS or DROP TRIGGER IF EXISTS
+func (q *Query) IfExists() QueryInterface {
+	q.ifExists = true
+	return q
+}
+
+// GetIfExists returns the ifExists flag of the query
+func (q *Query) GetIfExists() bool {
+	return q.ifExists
+}
+
+// GetViewQuery returns the select query of the view
+func (q *Query) GetViewQuery() QueryInterface {
+	return q.viewQuery
+}
+
+// GetTrigger returns the trigger of the query
+func (q *Query) GetTrigger() dto.Trigger {
+	return q.trigger
+}
+
 // OrderBy using this method you can specify the ORDER BY fields with the right direction to order.
 func (q *Query) OrderBy(field string, direction string) QueryInterface {
 	q.orderBys = append(q.orderBys, query.OrderByColumn{
@@ -313,6 +670,57 @@ func (q *Query) OrderBy(field string, direction string) QueryInterface {
 	return q
 }
 
+// ResetOrderBy removes all ORDER BY fields of the query
+func (q *Query) ResetOrderBy() QueryInterface {
+	q.orderBys = nil
+	return q
+}
+
+// ResetColumns removes all selected columns of the query
+func (q *Query) ResetColumns() QueryInterface {
+	q.columns = nil
+	return q
+}
+
+// ResetLock removes the locking clause of the query
+func (q *Query) ResetLock() QueryInterface {
+	q.lock = query.Lock{}
+	return q
+}
+
+// Clone returns the copy of the query, which can be modified without changes of the original query
+func (q *Query) Clone() QueryInterface {
+	clone := *q
+	clone.bindings = slices.Clone(q.bindings)
+	clone.withBindings = slices.Clone(q.withBindings)
+	clone.fromBindings = slices.Clone(q.fromBindings)
+	clone.joinBindings = slices.Clone(q.joinBindings)
+	clone.whereBindings = slices.Clone(q.whereBindings)
+	clone.havingBindings = slices.Clone(q.havingBindings)
+	clone.compoundBindings = slices.Clone(q.compoundBindings)
+	clone.columns = slices.Clone(q.columns)
+	clone.columnsDrop = slices.Clone(q.columnsDrop)
+	clone.columnsRename = slices.Clone(q.columnsRename)
+	clone.columnsModify = slices.Clone(q.columnsModify)
+	clone.indexAdd = slices.Clone(q.indexAdd)
+	clone.indexDrop = slices.Clone(q.indexDrop)
+	clone.foreignKeysAdd = slices.Clone(q.foreignKeysAdd)
+	clone.foreignKeysDrop = slices.Clone(q.foreignKeysDrop)
+	clone.constraintsAdd = slices.Clone(q.constraintsAdd)
+	clone.constraintsDrop = slices.Clone(q.constraintsDrop)
+	clone.wheres = slices.Clone(q.wheres)
+	clone.joins = slices.Clone(q.joins)
+	clone.orderBys = slices.Clone(q.orderBys)
+	clone.groupBys = slices.Clone(q.groupBys)
+	clone.havings = slices.Clone(q.havings)
+	clone.compounds = slices.Clone(q.compounds)
+	clone.with = slices.Clone(q.with)
+	clone.windows = slices.Clone(q.windows)
+	clone.primaryKeyValues = slices.Clone(q.primaryKeyValues)
+
+	return &clone
+}
+
 // Limit using this method you can set the limitation for result of your query
 func (q *Query) Limit(limit query.Limit) QueryInterface {
 	q.limit = limit
@@ -325,26 +733,165 @@ func (q *Query) GroupBy(field string) QueryInterface {
 	return q
 }
 
+// Distinct method can be used for the SELECT DISTINCT statement
+func (q *Query) Distinct() QueryInterface {
+	q.distinct = true
+	return q
+}
+
+// ForUpdate method adds the FOR UPDATE locking clause to the select query. The selected rows will be locked till the end of the transaction
+func (q *Query) ForUpdate() QueryInterface {
+	q.lock.Strength = query.ForUpdateLock
+	return q
+}
+
+// ForShare method adds the FOR SHARE locking clause to the select query. The selected rows can be read, but cannot be modified by other transactions
+func (q *Query) ForShare() QueryInterface {
+	q.lock.Strength = query.ForShareLock
+	return q
+}
+
+// NoWait method sets the NOWAIT option of the locking clause. If the locking clause is not specified, the FOR UPDATE will be used
+func (q *Query) NoWait() QueryInterface {
+	return q.lockOption(query.NoWaitLockOption)
+}
+
+// SkipLocked method sets the SKIP LOCKED option of the locking clause. If the locking clause is not specified, the FOR UPDATE will be used
+func (q *Query) SkipLocked() QueryInterface {
+	return q.lockOption(query.SkipLockedLockOption)
+}
+
+func (q *Query) lockOption(option string) QueryInterface {
+	if q.lock.Strength == "" {
+		q.lock.Strength = query.ForUpdateLock
+	}
+
+	q.lock.Option = option
+	return q
+}
+
+// Window method adds the named window to the WINDOW clause. This window can be referenced by the name in the window functions
+func (q *Query) Window(name string, window query.Window) QueryInterface {
+	window.Name = name
+	q.windows = append(q.windows, window)
+	return q
+}
+
+// With method adds the common table expression to the query. The name can contain the list of columns, eg: tree(id, parent_id)
+func (q *Query) With(name string, cte QueryInterface) QueryInterface {
+	return q.addCommonTableExpression(name, cte, false)
+}
+
+// WithRecursive method adds the recursive common table expression to the query
+func (q *Query) WithRecursive(name string, cte QueryInterface) QueryInterface {
+	return q.addCommonTableExpression(name, cte, true)
+}
+
+func (q *Query) addCommonTableExpression(name string, cte QueryInterface, recursive bool) QueryInterface {
+	q.with = append(q.with, CommonTableExpression{
+		Name:      name,
+		Query:     cte,
+		Recursive: recursive,
+	})
+	q.withBindings = append(q.withBindings, cte.GetBindings()...)
+	return q
+}
+
+// Union combines the results of the query with the results of selected query. The OrderBy and Limit of the current query will be applied to the combined result
+func (q *Query) Union(compound QueryInterface) QueryInterface {
+	return q.compound(UnionCompound, compound)
+}
+
+// UnionAll combines the results of the query with the results of selected query without removal of duplicates
+func (q *Query) UnionAll(compound QueryInterface) QueryInterface {
+	return q.compound(UnionAllCompound, compound)
+}
+
+// Intersect returns the rows which exist in the results of the query and selected query
+func (q *Query) Intersect(compound QueryInterface) QueryInterface {
+	return q.compound(IntersectCompound, compound)
+}
+
+// Except returns the rows of the query which do not exist in the results of selected query
+func (q *Query) Except(compound QueryInterface) QueryInterface {
+	return q.compound(ExceptCompound, compound)
+}
+
+func (q *Query) compound(compoundType string, compound QueryInterface) QueryInterface {
+	q.compounds = append(q.compounds, Compound{
+		Type:  compoundType,
+		Query: compound,
+	})
+	q.compoundBindings = append(q.compoundBindings, compound.GetBindings()...)
+	return q
+}
+
 // Where method needed for WHERE clause configuration.
 func (q *Query) Where(where query.Where) QueryInterface {
-	switch v := where.First.(type) {
-	case query.Bind:
-		q.AddBinding(v)
-		where.First = "?"
-	}
+	q.wheres = append(q.wheres, bindWhere(where, &q.whereBindings))
+	return q
+}
+
+// Having method needed for HAVING clause configuration. It should be used in the combination with GroupBy method.
+func (q *Query) Having(having query.Where) QueryInterface {
+	q.havings = append(q.havings, bindWhere(having, &q.havingBindings))
+	return q
+}
+
+// bindWhere replaces the query.Bind operands of the condition with the placeholders and collects the bindings
+func bindWhere(where query.Where, bindings *[]query.Bind) query.Where {
+	where.First = bindWhereOperand(where.First, bindings)
+	where.Second = bindWhereOperand(where.Second, bindings)
 
-	switch v := where.Second.(type) {
+	return where
+}
+
+func bindWhereOperand(operand interface{}, bindings *[]query.Bind) interface{} {
+	switch v := operand.(type) {
 	case query.Bind:
-		q.AddBinding(v)
-		where.Second = "?"
+		*bindings = append(*bindings, v)
+		return "?"
+	case []query.Bind:
+		//The list of values, for example for IN clause, will be expanded into the list of placeholders
+		var placeholders []string
+		for _, bind := range v {
+			*bindings = append(*bindings, bind)
+			placeholders = append(placeholders, "?")
+		}
+
+		return fmt.Sprintf("(%s)", strings.Join(placeholders, ", "))
+	case query.Where:
+		return bindWhere(v, bindings)
+	case query.Group:
+		var group query.Group
+		for _, where := range v {
+			group = append(group, bindWhere(where, bindings))
+		}
+
+		return group
+	case query.Expression:
+		*bindings = append(*bindings, v.Bindings...)
+		return v.SQL
+	case QueryInterface:
+		*bindings = append(*bindings, v.GetBindings()...)
 	}
 
-	q.wheres = append(q.wheres, where)
-	return q
+	return operand
 }
 
 // Join method can be used for specification of JOIN clause.
 func (q *Query) Join(join query.Join) QueryInterface {
+	switch v := join.Query.(type) {
+	case QueryInterface:
+		q.joinBindings = append(q.joinBindings, v.GetBindings()...)
+	}
+
+	var conditions []query.Where
+	for _, where := range join.On {
+		conditions = append(conditions, bindWhere(where, &q.joinBindings))
+	}
+
+	join.On = conditions
 	q.joins = append(q.joins, join)
 	return q
 }
@@ -361,6 +908,18 @@ func (q *Query) DropColumn(column dto.ModelField) QueryInterface {
 	return q
 }
 
+// RenameColumn the method which identifies which column we need to rename for selected model in Alter method
+func (q *Query) RenameColumn(original string, newName string) QueryInterface {
+	q.columnsRename = append(q.columnsRename, dto.ColumnRename{From: original, To: newName})
+	return q
+}
+
+// ModifyColumn the method which identifies which column definition we need to change for selected model in Alter method
+func (q *Query) ModifyColumn(column dto.ModelField) QueryInterface {
+	q.columnsModify = append(q.columnsModify, column)
+	return q
+}
+
 // AddForeignKey the method which identifies which foreign key we need to add for selected model in Alter method
 func (q *Query) AddForeignKey(field dto.ForeignKey) QueryInterface {
 	q.foreignKeysAdd = append(q.foreignKeysAdd, field)
@@ -391,6 +950,18 @@ func (q *Query) DropIndex(field dto.Index) QueryInterface {
 	return q
 }
 
+// AddConstraint the method which identifies which table constraint we need to add for selected model in Create or Alter method
+func (q *Query) AddConstraint(constraint dto.Constraint) QueryInterface {
+	q.constraintsAdd = append(q.constraintsAdd, constraint)
+	return q
+}
+
+// DropConstraint the method which identifies which table constraint we need to drop for selected model in Alter method
+func (q *Query) DropConstraint(constraint dto.Constraint) QueryInterface {
+	q.constraintsDrop = append(q.constraintsDrop, constraint)
+	return q
+}
+
 // Values sets the values, which will be used for insert queries
 func (q *Query) Values(values interface{}) QueryInterface {
 	q.values = values
@@ -416,6 +987,51 @@ func (q *Query) Drop(model dto.ModelInterface) QueryInterface {
 	return q
 }
 
+// CreateFromSelect method will return the query object for the table creation from the select query: CREATE TABLE name AS SELECT ...
+func (q *Query) CreateFromSelect(name string, selectQuery QueryInterface) QueryInterface {
+	q.queryType = CreateType
+	q.From(name)
+	q.values = selectQuery
+	return q
+}
+
+// Truncate method will return the query object, which removes all rows of the table
+func (q *Query) Truncate(model dto.ModelInterface) QueryInterface {
+	q.queryType = TruncateType
+	q.From(model)
+	return q
+}
+
+// CreateView method will return the query object for the view creation. The select query is used as the definition of the view
+func (q *Query) CreateView(name string, viewQuery QueryInterface) QueryInterface {
+	q.queryType = CreateViewType
+	q.From(name)
+	q.viewQuery = viewQuery
+	return q
+}
+
+// DropView method will return the query object for the view drop
+func (q *Query) DropView(name string) QueryInterface {
+	q.queryType = DropViewType
+	q.From(name)
+	return q
+}
+
+// CreateTrigger method will return the query object for the trigger creation
+func (q *Query) CreateTrigger(trigger dto.Trigger) QueryInterface {
+	q.queryType = CreateTriggerType
+	q.From(trigger.Table)
+	q.trigger = trigger
+	return q
+}
+
+// DropTrigger method will return the query object for the trigger drop
+func (q *Query) DropTrigger(name string) QueryInterface {
+	q.queryType = DropTriggerType
+	q.trigger = dto.Trigger{Name: name}
+	return q
+}
+
 // Rename will rename the table to the new table name
 func (q *Query) Rename(table string, newTableName string) QueryInterface {
 	q.queryType = RenameType
@@ -442,6 +1058,8 @@ func (q *Query) Select(columns interface{}) QueryInterface {
 				})
 			case dto.ModelField:
 				q.AddColumn(v)
+			case query.WindowFunction:
+				q.columns = append(q.columns, v)
 			}
 		}
 	case string:
@@ -463,6 +1081,11 @@ func (q *Query) Insert(model dto.ModelInterface) QueryInterface {
 	for _, field := range model.GetColumns() {
 		switch v := field.(type) {
 		case dto.ModelField:
+			//The values of the generated columns are computed by the database
+			if v.Generated != "" {
+				break
+			}
+
 			q.AddColumn(v)
 			//For primary keys we don't
 			if v.Value == nil && v.IsPrimaryKey {
@@ -488,7 +1111,7 @@ func (q *Query) Update(model dto.ModelInterface) QueryInterface {
 	for _, field := range model.GetColumns() {
 		switch v := field.(type) {
 		case dto.ModelField:
-			if v.IsPrimaryKey {
+			if v.IsPrimaryKey || v.Generated != "" {
 				continue
 			}
 
@@ -505,6 +1128,214 @@ func (q *Query) Update(model dto.ModelInterface) QueryInterface {
 	return q
 }
 
+// Save method inserts the model, if its primary key is empty. Otherwise, all fields of the model will be updated by the primary key.
+// For the composite primary key the model will be inserted, if at least one of the primary key values is empty
+func (q *Query) Save(model dto.ModelInterface) QueryInterface {
+	var (
+		keys    = model.GetPrimaryKeys()
+		isEmpty = len(keys) == 0
+	)
+	for _, key := range keys {
+		if isEmptyValue(model.GetField(key.Name).Value) {
+			isEmpty = true
+		}
+	}
+
+	if isEmpty {
+		return q.Insert(model)
+	}
+
+	q.queryType = UpdateType
+	q.destination = model
+	for _, field := range model.GetColumns() {
+		switch v := field.(type) {
+		case dto.ModelField:
+			if v.Generated != "" || isPrimaryKeyField(keys, v.Name) {
+				continue
+			}
+
+			q.Set(v.Name, v.Value)
+		}
+	}
+
+	return q.wherePrimaryKey(keys, primaryKeyValues(model))
+}
+
+// DeleteModel method deletes the model by its primary key. If the model does not have the primary key, nothing will be deleted
+func (q *Query) DeleteModel(model dto.ModelInterface) QueryInterface {
+	q.queryType = DeleteType
+	q.destination = model
+
+	return q.wherePrimaryKey(model.GetPrimaryKeys(), primaryKeyValues(model))
+}
+
+// Find method selects the model by the primary key. For the composite primary key the values should be in the order of the GetPrimaryKeys method result
+func (q *Query) Find(model dto.ModelInterface, primaryKey ...interface{}) QueryInterface {
+	q.Select([]interface{}{}).From(model)
+
+	//The values are checked before the execution, so the missing values are not compared with NULL
+	q.primaryKeyValues = append([]interface{}{}, primaryKey...)
+
+	return q.wherePrimaryKey(model.GetPrimaryKeys(), primaryKey)
+}
+
+// GetPrimaryKeyValues returns the values of the primary key, which were specified by Find method
+func (q *Query) GetPrimaryKeyValues() []interface{} {
+	return q.primaryKeyValues
+}
+
+// wherePrimaryKey adds the conditions by the primary key columns. If there are no primary key columns, the always-false condition will be added
+func (q *Query) wherePrimaryKey(keys []dto.ModelField, values []interface{}) QueryInterface {
+	if len(keys) == 0 {
+		return q.Where(query.Where{First: "1", Operator: "=", Second: "0"})
+	}
+
+	for i, key := range keys {
+		var value interface{}
+		if i < len(values) {
+			value = values[i]
+		}
+
+		q.Where(query.Eq(key.Name, value))
+	}
+
+	return q
+}
+
+// primaryKeyValues returns the values of the primary key columns. The original values are used, if they are known
+func primaryKeyValues(model dto.ModelInterface) (values []interface{}) {
+	for _, key := range model.GetPrimaryKeys() {
+		value := model.Original(key.Name)
+		if value == nil {
+			value = model.GetField(key.Name).Value
+		}
+
+		values = append(values, value)
+	}
+
+	return values
+}
+
+func isPrimaryKeyField(keys []dto.ModelField, name string) bool {
+	for _, key := range keys {
+		if key.Name == name {
+			return true
+		}
+	}
+
+	return false
+}
+
+func isEmptyValue(value interface{}) bool {
+	return value == nil || reflect.ValueOf(value).IsZero()
+}
+
+// UpdateDirty method should be used when you need to update only the fields of the model, which were changed after the model was loaded from the database.
+// The condition by the primary key is added automatically. The original value of the primary key is used, if it is known.
+// If the model does not have the primary key, nothing will be updated
+func (q *Query) UpdateDirty(model dto.ModelInterface) QueryInterface {
+	q.queryType = UpdateType
+	q.destination = model
+
+	keys := model.GetPrimaryKeys()
+	for _, field := range model.DirtyFields() {
+		if field.IsPrimaryKey || field.Generated != "" || isPrimaryKeyField(keys, field.Name) {
+			continue
+		}
+
+		q.Set(field.Name, field.Value)
+	}
+
+	return q.wherePrimaryKey(keys, primaryKeyValues(model))
+}
+
+// UpdateTable method should be used when you need to update only the columns, which are specified by Set, Increment and Decrement methods.
+// It receives the dto.ModelInterface object or the table name and returns the updated QueryInterface object.
+func (q *Query) UpdateTable(table interface{}) QueryInterface {
+	q.queryType = UpdateType
+	q.From(table)
+
+	return q
+}
+
+// Set method adds the column assignment to the UPDATE query. The value can be the query.Expression for the raw sql expression, eg: query.Expression{SQL: "CURRENT_TIMESTAMP"},
+// the nested select query or the value, which will be bound. If the column is already updated by the query, its value will be replaced
+func (q *Query) Set(column string, value interface{}) QueryInterface {
+	var (
+		assignment = query.Assignment{
+			Column: column,
+			Value:  value,
+		}
+		isReplaced bool
+	)
+	for i, c := range q.columns {
+		switch v := c.(type) {
+		case dto.ModelField:
+			isReplaced = v.Name == column
+		case query.Assignment:
+			isReplaced = v.Column == column
+		}
+
+		if isReplaced {
+			q.columns[i] = assignment
+			break
+		}
+	}
+
+	if !isReplaced {
+		q.columns = append(q.columns, assignment)
+	}
+
+	//The bindings of the SET clause should follow the order of the columns
+	q.bindings = nil
+	for _, c := range q.columns {
+		switch v := c.(type) {
+		case dto.ModelField:
+			if v.IsPrimaryKey {
+				continue
+			}
+
+			q.AddBinding(query.Bind{
+				Field: "?",
+				Value: v.Value,
+			})
+		case query.Assignment:
+			q.bindings = append(q.bindings, assignmentBindings(v)...)
+		}
+	}
+
+	return q
+}
+
+// Increment method increases the value of the column in the UPDATE query: column = column + value
+func (q *Query) Increment(column string, value interface{}) QueryInterface {
+	return q.Set(column, query.Expression{
+		SQL:      fmt.Sprintf("%s + ?", column),
+		Bindings: []query.Bind{{Field: column, Value: value}},
+	})
+}
+
+// Decrement method decreases the value of the column in the UPDATE query: column = column - value
+func (q *Query) Decrement(column string, value interface{}) QueryInterface {
+	return q.Set(column, query.Expression{
+		SQL:      fmt.Sprintf("%s - ?", column),
+		Bindings: []query.Bind{{Field: column, Value: value}},
+	})
+}
+
+func assignmentBindings(assignment query.Assignment) []query.Bind {
+	switch v := assignment.Value.(type) {
+	case query.Expression:
+		return v.Bindings
+	case query.Bind:
+		return []query.Bind{v}
+	case QueryInterface:
+		return v.GetBindings()
+	}
+
+	return []query.Bind{{Field: assignment.Column, Value: assignment.Value}}
+}
+
 // Alter method should be used when you need to alter selected table.
 // It should be used from the beginning of your query, to specify the initial query string and with combination one of the methods AddColumn, DropColumn, AddIndex, DropIndex, AddForeignKey, DropForeignKey
 // This method receives the dto.ModelInterface object and returns the updated query.AlterQuery object.
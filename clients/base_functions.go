@@ -2,7 +2,10 @@ package clients
 
 import (
 	"database/sql"
+	"errors"
 	"fmt"
+	"math"
+	"regexp"
 	"strconv"
 	"strings"
 
@@ -11,26 +14,44 @@ import (
 )
 
 const TempTablePrefix = "temp_"
+
 const OldTablePrefix = "old_"
 
+var triggerBodyRegexp = regexp.MustCompile(`(?is)^BEGIN\s+(.*?)\s*END$`)
+
 func toSql(c BaseClientInterface, q QueryInterface) string {
 	switch q.GetQueryType() {
 	case SelectType:
-		return prepareSelectQuery(q)
+		return c.prepareSelectQuery(q)
 	case InsertType:
 		return prepareInsertQuery(q)
 	case DeleteType:
-		return prepareDeleteQuery(q)
+		return c.prepareDeleteQuery(q)
 	case AlterType:
 		return c.prepareAlterQuery(q)
 	case RenameType:
 		return prepareRenameTableQuery(q)
 	case UpdateType:
-		return prepareUpdateQuery(q)
+		//The UPDATE query without the columns to update cannot be generated, eg: for the model without changes
+		if isEmptyUpdateQuery(q) {
+			return ""
+		}
+
+		return c.prepareUpdateQuery(q)
 	case DropType:
 		return prepareDropQuery(q)
+	case TruncateType:
+		return c.prepareTruncateQuery(q)
 	case CreateType:
 		return c.prepareCreateQuery(q)
+	case CreateViewType:
+		return c.prepareCreateViewQuery(q)
+	case DropViewType:
+		return prepareDropViewQuery(q)
+	case CreateTriggerType:
+		return c.prepareCreateTriggerQuery(q)
+	case DropTriggerType:
+		return prepareDropTriggerQuery(q)
 	case TransactionBegin:
 		return c.prepareTransactionBegin()
 	case TransactionCommit:
@@ -42,19 +63,61 @@ func toSql(c BaseClientInterface, q QueryInterface) string {
 	return ""
 }
 
+// setResultModelDestination sets the table name and the primary key of the selected model, so the model can be used in the UPDATE and DELETE queries
+func setResultModelDestination(model *dto.BaseModel, destination dto.ModelInterface) {
+	if destination == nil {
+		return
+	}
+
+	model.TableName = destination.GetTableName()
+
+	var keys = destination.GetPrimaryKeys()
+	if len(keys) == 1 {
+		if field := model.GetField(keys[0].Name); field.Name != "" {
+			keys[0].Value = field.Value
+			model.PrimaryKey = keys[0]
+		}
+
+		return
+	}
+
+	//For the composite primary key the fields with IsPrimaryKey flag are used
+	for i, field := range model.Fields {
+		switch v := field.(type) {
+		case dto.ModelField:
+			if isPrimaryKeyField(keys, v.Name) {
+				v.IsPrimaryKey = true
+				model.Fields[i] = v
+			}
+		}
+	}
+}
+
 // prepareSelectQuery method prepares the select query statement
 func prepareSelectQuery(q QueryInterface) string {
+	var queryStr = generateWithStr(q)
+	queryStr += generateSelectCoreStr(q, q.GetJoins())
+	queryStr += generateSelectTailStr(q)
+
+	return queryStr
+}
+
+// generateSelectCoreStr generates the select statement from the SELECT till the WINDOW clause using selected joins
+func generateSelectCoreStr(q QueryInterface, joins []query.Join) string {
 	var queryStr = "SELECT "
+	if q.GetDistinct() {
+		queryStr += "DISTINCT "
+	}
 
 	//Target we need to prepare the select columns list
 	queryStr += generateSelectColumnsStr(q.GetColumns())
 
 	//Now we need to create FROM string
-	queryStr += fmt.Sprintf(" FROM %s", q.GetDestination().GetTableName())
+	queryStr += fmt.Sprintf(" FROM %s", generateFromStr(q))
 
 	//Next step is appending the join statements if there are joins specified
-	if len(q.GetJoins()) > 0 {
-		queryStr += fmt.Sprintf(" %s", generateJoinsStr(q.GetJoins()))
+	if len(joins) > 0 {
+		queryStr += fmt.Sprintf(" %s", generateJoinsStr(joins))
 	}
 
 	if len(q.GetWheres()) > 0 {
@@ -65,6 +128,26 @@ func prepareSelectQuery(q QueryInterface) string {
 		queryStr += fmt.Sprintf(" %s", generateGroupByStr(q.GetGroupBy()))
 	}
 
+	if len(q.GetHavings()) > 0 {
+		queryStr += fmt.Sprintf(" %s", generateHavingStr(q.GetHavings()))
+	}
+
+	if len(q.GetWindows()) > 0 {
+		queryStr += fmt.Sprintf(" %s", generateWindowsClauseStr(q.GetWindows()))
+	}
+
+	return queryStr
+}
+
+// generateSelectTailStr generates the compound queries, ORDER BY and LIMIT clauses of the select statement
+func generateSelectTailStr(q QueryInterface) string {
+	var queryStr string
+
+	//The ORDER BY and LIMIT clauses will be applied to the combined result of the compound queries
+	if len(q.GetCompounds()) > 0 {
+		queryStr += fmt.Sprintf(" %s", generateCompoundsStr(q.GetCompounds()))
+	}
+
 	if len(q.GetOrderBy()) > 0 {
 		queryStr += fmt.Sprintf(" %s", generateOrderByStr(q.GetOrderBy()))
 	}
@@ -76,23 +159,266 @@ func prepareSelectQuery(q QueryInterface) string {
 	return queryStr
 }
 
+func generateFromStr(q QueryInterface) string {
+	if q.GetFromQuery() != nil {
+		return fmt.Sprintf("%s AS %s", generateSubQueryStr(q.GetFromQuery()), q.GetDestination().GetTableName())
+	}
+
+	return q.GetDestination().GetTableName()
+}
+
+// generateSubQueryStr generates the nested select query, which can be used in FROM, JOIN, WHERE clauses and INSERT ... SELECT statement
+func generateSubQueryStr(q QueryInterface) string {
+	return fmt.Sprintf("(%s)", prepareSelectQuery(q))
+}
+
+func generateCompoundsStr(compounds []Compound) string {
+	var result []string
+	for i, compound := range compounds {
+		var compoundStr = prepareSelectQuery(compound.Query)
+
+		//The compound query with own ORDER BY or LIMIT cannot be used directly, so we wrap it into the derived table
+		if len(compound.Query.GetOrderBy()) > 0 || compound.Query.GetLimit() != *new(query.Limit) {
+			compoundStr = fmt.Sprintf("SELECT * FROM (%s) AS compound_%d", compoundStr, i)
+		}
+
+		result = append(result, fmt.Sprintf("%s %s", compound.Type, compoundStr))
+	}
+
+	return strings.Join(result, " ")
+}
+
+// generateWithStr generates the WITH clause for the common table expressions of the query
+func generateWithStr(q QueryInterface) string {
+	if len(q.GetWith()) == 0 {
+		return ""
+	}
+
+	var (
+		recursive   bool
+		expressions []string
+	)
+	for _, cte := range q.GetWith() {
+		if cte.Recursive {
+			recursive = true
+		}
+
+		expressions = append(expressions, fmt.Sprintf("%s AS %s", cte.Name, generateSubQueryStr(cte.Query)))
+	}
+
+	//The RECURSIVE keyword is defined once for the whole WITH clause
+	var resultStr = "WITH "
+	if recursive {
+		resultStr += "RECURSIVE "
+	}
+
+	return fmt.Sprintf("%s%s ", resultStr, strings.Join(expressions, ", "))
+}
+
+// hasCompound checks if the query or its compound queries use one of selected set operations
+func hasCompound(q QueryInterface, types ...string) bool {
+	for _, compound := range q.GetCompounds() {
+		for _, compoundType := range types {
+			if compound.Type == compoundType {
+				return true
+			}
+		}
+
+		if hasCompound(compound.Query, types...) {
+			return true
+		}
+	}
+
+	return false
+}
+
 func generateJoinsStr(joins []query.Join) string {
-	var joinsStr string
+	var result []string
+	for _, join := range joins {
+		result = append(result, generateJoinStr(join))
+	}
+
+	return strings.Join(result, " ")
+}
+
+func generateJoinStr(join query.Join) string {
+	var (
+		target = join.Target.Table
+		alias  = join.Alias
+	)
+	switch v := join.Query.(type) {
+	case QueryInterface:
+		//For the subquery the target table name is used as alias, if the alias is not specified
+		if alias == "" {
+			alias = join.Target.Table
+		}
+
+		target = generateSubQueryStr(v)
+	}
+
+	if alias != "" {
+		target = fmt.Sprintf("%s AS %s", target, alias)
+	} else {
+		alias = join.Target.Table
+	}
+
+	var joinStr = fmt.Sprintf("%s %s", generateJoinTypeStr(join.Type), target)
+	if len(join.Using) > 0 {
+		return fmt.Sprintf("%s USING (%s)", joinStr, strings.Join(join.Using, ", "))
+	}
+
+	var conditions []string
+	if join.Target.Key != "" {
+		condition := join.Condition
+		if condition == "" {
+			condition = "="
+		}
+
+		conditions = append(conditions, fmt.Sprintf("%s.%s %s %s.%s", alias, join.Target.Key, condition, join.With.Table, join.With.Key))
+	}
+
+	if len(join.On) > 0 {
+		onStr := conditionsToStr(join.On)
+		if len(conditions) > 0 && len(join.On) > 1 {
+			onStr = fmt.Sprintf("(%s)", onStr)
+		}
+
+		conditions = append(conditions, onStr)
+	}
+
+	//The CROSS JOIN does not have the conditions
+	if len(conditions) == 0 {
+		return joinStr
+	}
+
+	return fmt.Sprintf("%s ON (%s)", joinStr, strings.Join(conditions, " AND "))
+}
+
+func generateJoinTypeStr(joinType string) string {
+	switch strings.ToUpper(joinType) {
+	case "":
+		return "JOIN"
+	case query.FullJoinType:
+		return "FULL OUTER JOIN"
+	}
+
+	return fmt.Sprintf("%s JOIN", strings.ToUpper(joinType))
+}
+
+// countJoins returns the number of joins with selected type
+func countJoins(joins []query.Join, joinType string) int {
+	var count int
 	for _, join := range joins {
-		joinsStr += fmt.Sprintf("%s JOIN %s ON (%s %s %s)",
-			strings.ToUpper(join.Type),
-			join.Target.Table,
-			fmt.Sprintf("%s.%s", join.Target.Table, join.Target.Key),
-			join.Condition,
-			fmt.Sprintf("%s.%s", join.With.Table, join.With.Key),
-		)
+		if strings.ToUpper(join.Type) == joinType {
+			count++
+		}
 	}
 
-	return joinsStr
+	return count
+}
+
+// hasNestedJoins checks if the nested queries of the query use the joins with selected type
+func hasNestedJoins(q QueryInterface, joinType string) bool {
+	for _, subQuery := range getSubQueries(q) {
+		if countJoins(subQuery.GetJoins(), joinType) > 0 || hasNestedJoins(subQuery, joinType) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// getSubQueries returns the nested queries of the query: the common table expressions, the derived table, the subqueries of the joins, conditions and assignments,
+// the compound queries and the select query of INSERT ... SELECT statement
+func getSubQueries(q QueryInterface) (result []QueryInterface) {
+	for _, cte := range q.GetWith() {
+		result = append(result, cte.Query)
+	}
+
+	if q.GetFromQuery() != nil {
+		result = append(result, q.GetFromQuery())
+	}
+
+	for _, join := range q.GetJoins() {
+		switch v := join.Query.(type) {
+		case QueryInterface:
+			result = append(result, v)
+		}
+
+		result = append(result, getConditionsSubQueries(join.On)...)
+	}
+
+	result = append(result, getConditionsSubQueries(q.GetWheres())...)
+	result = append(result, getConditionsSubQueries(q.GetHavings())...)
+
+	for _, compound := range q.GetCompounds() {
+		result = append(result, compound.Query)
+	}
+
+	for _, column := range q.GetColumns() {
+		switch v := column.(type) {
+		case query.Assignment:
+			switch value := v.Value.(type) {
+			case QueryInterface:
+				result = append(result, value)
+			}
+		}
+	}
+
+	switch v := q.GetValues().(type) {
+	case QueryInterface:
+		result = append(result, v)
+	}
+
+	return result
+}
+
+// getConditionsSubQueries returns the subqueries, which are used as the operands of the conditions
+func getConditionsSubQueries(wheres []query.Where) (result []QueryInterface) {
+	for _, where := range wheres {
+		for _, operand := range []interface{}{where.First, where.Second} {
+			switch v := operand.(type) {
+			case QueryInterface:
+				result = append(result, v)
+			case query.Where:
+				result = append(result, getConditionsSubQueries([]query.Where{v})...)
+			case query.Group:
+				result = append(result, getConditionsSubQueries(v)...)
+			}
+		}
+	}
+
+	return result
+}
+
+// replaceJoinsType returns the copy of the joins, where the joins with selected type are replaced by the new type
+func replaceJoinsType(joins []query.Join, joinType string, newJoinType string) []query.Join {
+	var result []query.Join
+	for _, join := range joins {
+		if strings.ToUpper(join.Type) == joinType {
+			join.Type = newJoinType
+		}
+
+		result = append(result, join)
+	}
+
+	return result
 }
 
 func generateWhereStr(wheres []query.Where) string {
-	var resultStr = "WHERE "
+	return generateConditionsStr("WHERE", wheres)
+}
+
+func generateHavingStr(havings []query.Where) string {
+	return generateConditionsStr("HAVING", havings)
+}
+
+func generateConditionsStr(clause string, wheres []query.Where) string {
+	return strings.TrimSpace(fmt.Sprintf("%s %s", clause, conditionsToStr(wheres)))
+}
+
+func conditionsToStr(wheres []query.Where) string {
+	var resultStr string
 
 	for i, where := range wheres {
 		//If we have the type of WHERE clause specified and this is not first element, we do set the type.
@@ -103,7 +429,7 @@ func generateWhereStr(wheres []query.Where) string {
 		resultStr += whereToStr(where)
 	}
 
-	return strings.TrimSpace(resultStr)
+	return resultStr
 }
 
 func whereToStr(where query.Where) string {
@@ -115,6 +441,17 @@ func whereToStr(where query.Where) string {
 	case query.Where:
 		resultStr += whereToStr(w)
 		isFirstIsWhere = true
+	case QueryInterface:
+		resultStr += generateSubQueryStr(w)
+		resultStr += fmt.Sprintf(" %s ", where.Operator)
+	case query.Group:
+		resultStr += fmt.Sprintf("(%s)", conditionsToStr(w))
+		if where.Operator != "" {
+			resultStr += fmt.Sprintf(" %s ", where.Operator)
+		}
+	case nil:
+		//The operators like EXISTS can be used without first operand
+		resultStr += fmt.Sprintf("%s ", where.Operator)
 	default:
 		resultStr += fmt.Sprintf("%s", where.First)
 		resultStr += fmt.Sprintf(" %s ", where.Operator)
@@ -124,6 +461,12 @@ func whereToStr(where query.Where) string {
 	case query.Where:
 		resultStr += fmt.Sprintf(" %s %s", w.GetType(), whereToStr(w))
 		isSecondIsWhere = true
+	case QueryInterface:
+		resultStr += generateSubQueryStr(w)
+	case query.Group:
+		resultStr += fmt.Sprintf("(%s)", conditionsToStr(w))
+	case nil:
+		//The groups of conditions can be used without second operand
 	default:
 		resultStr += fmt.Sprintf("%s", where.Second)
 	}
@@ -148,12 +491,79 @@ func generateSelectColumnsStr(columns []interface{}) string {
 			preparedColumns = append(preparedColumns, v)
 		case dto.ModelField:
 			preparedColumns = append(preparedColumns, v.Name)
+		case query.WindowFunction:
+			preparedColumns = append(preparedColumns, generateWindowFunctionStr(v))
 		}
 	}
 
 	return strings.Join(preparedColumns, ", ")
 }
 
+func generateWindowFunctionStr(function query.WindowFunction) string {
+	var resultStr = fmt.Sprintf("%s OVER ", function.Function)
+
+	//The reference to the named window can be used without brackets
+	if function.Window.Name != "" && len(function.Window.PartitionBy) == 0 && len(function.Window.OrderBy) == 0 && function.Window.Frame == "" {
+		resultStr += function.Window.Name
+	} else {
+		resultStr += fmt.Sprintf("(%s)", generateWindowStr(function.Window))
+	}
+
+	if function.Alias != "" {
+		resultStr += fmt.Sprintf(" AS %s", function.Alias)
+	}
+
+	return resultStr
+}
+
+func generateWindowStr(window query.Window) string {
+	var parts []string
+	if window.Name != "" {
+		parts = append(parts, window.Name)
+	}
+
+	if len(window.PartitionBy) > 0 {
+		parts = append(parts, fmt.Sprintf("PARTITION BY %s", strings.Join(window.PartitionBy, ", ")))
+	}
+
+	if len(window.OrderBy) > 0 {
+		parts = append(parts, generateOrderByStr(window.OrderBy))
+	}
+
+	if window.Frame != "" {
+		parts = append(parts, window.Frame)
+	}
+
+	return strings.Join(parts, " ")
+}
+
+func generateWindowsClauseStr(windows []query.Window) string {
+	var result []string
+	for _, window := range windows {
+		var definition = window
+		definition.Name = ""
+		result = append(result, fmt.Sprintf("%s AS (%s)", window.Name, generateWindowStr(definition)))
+	}
+
+	return fmt.Sprintf("WINDOW %s", strings.Join(result, ", "))
+}
+
+// hasWindowFunctions checks if the query uses the window functions
+func hasWindowFunctions(q QueryInterface) bool {
+	if len(q.GetWindows()) > 0 {
+		return true
+	}
+
+	for _, column := range q.GetColumns() {
+		switch column.(type) {
+		case query.WindowFunction:
+			return true
+		}
+	}
+
+	return false
+}
+
 func generateGroupByStr(groupBys []string) string {
 	var preparedColumns []string
 	//Target we need to prepare the select columns list
@@ -173,6 +583,20 @@ func generateOrderByStr(orderBys []query.OrderByColumn) string {
 }
 
 func generateLimitStr(limit query.Limit) string {
+	if limit.Offset != 0 || limit.Count != 0 {
+		var count = limit.Count
+		if count == 0 {
+			//The offset cannot be used without the limit, so we use the maximum number of rows
+			count = math.MaxInt64
+		}
+
+		if limit.Offset == 0 {
+			return fmt.Sprintf("LIMIT %d", count)
+		}
+
+		return fmt.Sprintf("LIMIT %d OFFSET %d", count, limit.Offset)
+	}
+
 	if limit.From == 0 && limit.To == 0 {
 		return ""
 	}
@@ -184,6 +608,14 @@ func generateLimitStr(limit query.Limit) string {
 	return fmt.Sprintf("LIMIT %d, %d", limit.From, limit.To)
 }
 
+func generateLockStr(lock query.Lock) string {
+	if lock.Option == "" {
+		return lock.Strength
+	}
+
+	return fmt.Sprintf("%s %s", lock.Strength, lock.Option)
+}
+
 func generateBindingsStr(bindings []query.Bind) string {
 	var items []string
 	for _, bind := range bindings {
@@ -213,6 +645,7 @@ func prepareInsertQuery(q QueryInterface) string {
 
 	switch v := q.GetValues().(type) {
 	case QueryInterface:
+		//The nested select query is used without the brackets here, because it is the source of the inserted rows
 		queryStr += fmt.Sprintf(" %s", prepareSelectQuery(v))
 	default:
 		queryStr += fmt.Sprintf(" VALUES (%s)", generateBindingsStr(q.GetBindings()))
@@ -221,47 +654,99 @@ func prepareInsertQuery(q QueryInterface) string {
 	return queryStr
 }
 
-// prepareUpdateQuery method prepares the update query statement
+// prepareUpdateQuery method prepares the update query statement. The joins are generated by the clients, because the multiple-table UPDATE syntax is different for each database
 func prepareUpdateQuery(q QueryInterface) string {
-	queryStr := fmt.Sprintf("UPDATE %s SET", q.GetDestination().GetTableName())
+	queryStr := generateWithStr(q)
+	queryStr += fmt.Sprintf("UPDATE %s SET %s", q.GetDestination().GetTableName(), generateAssignmentsStr(q.GetColumns()))
+
+	if len(q.GetWheres()) > 0 {
+		queryStr += fmt.Sprintf(" %s", generateWhereStr(q.GetWheres()))
+	}
 
+	return queryStr
+}
+
+func generateAssignmentsStr(columns []interface{}) string {
 	var toUpdate []string
-	for i, column := range q.GetColumns() {
+	for _, column := range columns {
 		switch v := column.(type) {
 		case dto.ModelField:
 			if v.IsPrimaryKey {
 				continue
 			}
 
-			if q.GetBindings()[i] == *(new(query.Bind)) {
-				break
-			}
-
-			toUpdate = append(toUpdate, fmt.Sprintf("%s = %s", v.Name, q.GetBindings()[i].Field))
+			toUpdate = append(toUpdate, fmt.Sprintf("%s = ?", v.Name))
+		case query.Assignment:
+			toUpdate = append(toUpdate, fmt.Sprintf("%s = %s", v.Column, generateAssignmentValueStr(v.Value)))
 		}
 	}
 
-	queryStr += fmt.Sprintf(" %s", strings.Join(toUpdate, ", "))
+	return strings.Join(toUpdate, ", ")
+}
+
+// countAssignments returns the number of the columns of the SET clause
+// isEmptyUpdateQuery checks if the UPDATE query does not have the columns to update, eg: UpdateDirty query for the model without changes.
+// This query does not change anything, so it is not executed
+func isEmptyUpdateQuery(q QueryInterface) bool {
+	return q.GetQueryType() == UpdateType && countAssignments(q.GetColumns()) == 0
+}
 
-	if len(q.GetJoins()) > 0 {
-		queryStr += fmt.Sprintf(" %s", generateJoinsStr(q.GetJoins()))
+func countAssignments(columns []interface{}) (count int) {
+	for _, column := range columns {
+		switch v := column.(type) {
+		case dto.ModelField:
+			if !v.IsPrimaryKey {
+				count++
+			}
+		case query.Assignment:
+			count++
+		}
 	}
 
-	if len(q.GetWheres()) > 0 {
-		queryStr += fmt.Sprintf(" %s", generateWhereStr(q.GetWheres()))
+	return count
+}
+
+// countWithBindings returns the number of the bindings of the common table expressions
+func countWithBindings(q QueryInterface) (count int) {
+	for _, cte := range q.GetWith() {
+		count += len(cte.Query.GetBindings())
 	}
 
-	return queryStr
+	return count
 }
 
-// prepareDeleteQuery method prepares the delete query statement
-func prepareDeleteQuery(q QueryInterface) string {
-	queryStr := fmt.Sprintf("DELETE FROM %s", q.GetDestination().GetTableName())
+// countAssignmentsBindings returns the number of the bindings of the SET clause
+func countAssignmentsBindings(columns []interface{}) (count int) {
+	for _, column := range columns {
+		switch v := column.(type) {
+		case dto.ModelField:
+			if !v.IsPrimaryKey {
+				count++
+			}
+		case query.Assignment:
+			count += len(assignmentBindings(v))
+		}
+	}
 
-	if len(q.GetJoins()) > 0 {
-		queryStr += fmt.Sprintf(" %s", generateJoinsStr(q.GetJoins()))
+	return count
+}
+
+func generateAssignmentValueStr(value interface{}) string {
+	switch v := value.(type) {
+	case query.Expression:
+		return v.SQL
+	case QueryInterface:
+		return generateSubQueryStr(v)
 	}
 
+	return "?"
+}
+
+// prepareDeleteQuery method prepares the delete query statement. The joins are generated by the clients, because the multiple-table DELETE syntax is different for each database
+func prepareDeleteQuery(q QueryInterface) string {
+	queryStr := generateWithStr(q)
+	queryStr += fmt.Sprintf("DELETE FROM %s", q.GetDestination().GetTableName())
+
 	if len(q.GetWheres()) > 0 {
 		queryStr += fmt.Sprintf(" %s", generateWhereStr(q.GetWheres()))
 	}
@@ -307,7 +792,13 @@ func generateColumnStr(column dto.ModelField) string {
 		resultStr += " unsigned"
 	}
 
-	if column.Default != nil {
+	if column.Collate != "" {
+		resultStr += fmt.Sprintf(" COLLATE %s", column.Collate)
+	}
+
+	if column.Generated != "" {
+		resultStr += fmt.Sprintf(" %s", generateGeneratedColumnStr(column))
+	} else if column.Default != nil {
 		resultStr += fmt.Sprintf(" DEFAULT %s", toSQLValue(column.Default))
 	}
 
@@ -324,6 +815,62 @@ func generateColumnStr(column dto.ModelField) string {
 	return resultStr
 }
 
+// generateGeneratedColumnStr generates the clause of the generated column: GENERATED ALWAYS AS (expression) STORED
+func generateGeneratedColumnStr(column dto.ModelField) string {
+	resultStr := fmt.Sprintf("GENERATED ALWAYS AS (%s)", column.Generated)
+	if column.GeneratedType != "" {
+		resultStr += fmt.Sprintf(" %s", column.GeneratedType)
+	}
+
+	return resultStr
+}
+
+// getColumnDefinitions returns the definitions of the columns, which are created or modified by the query
+func getColumnDefinitions(q QueryInterface) (result []dto.ModelField) {
+	var columns []interface{}
+	switch q.GetQueryType() {
+	case CreateType:
+		if q.GetDestination() == nil {
+			return result
+		}
+
+		columns = append([]interface{}{q.GetDestination().GetPrimaryKey()}, q.GetDestination().GetColumns()...)
+	case AlterType:
+		columns = q.GetColumns()
+	}
+
+	for _, column := range columns {
+		switch v := column.(type) {
+		case dto.ModelField:
+			result = append(result, v)
+		}
+	}
+
+	return append(result, q.GetColumnsToModify()...)
+}
+
+// hasColumnsOnUpdate checks if the ON UPDATE clause is specified for the columns of the query
+func hasColumnsOnUpdate(q QueryInterface) bool {
+	for _, column := range getColumnDefinitions(q) {
+		if column.OnUpdate != "" {
+			return true
+		}
+	}
+
+	return false
+}
+
+// hasGeneratedColumns checks if the generated columns are specified for the columns of the query
+func hasGeneratedColumns(q QueryInterface) bool {
+	for _, column := range getColumnDefinitions(q) {
+		if column.Generated != "" {
+			return true
+		}
+	}
+
+	return false
+}
+
 func generateForeignKeysStr(columns []dto.ForeignKey) string {
 	var result []string
 	for _, column := range columns {
@@ -333,13 +880,81 @@ func generateForeignKeysStr(columns []dto.ForeignKey) string {
 	return strings.Join(result, ",\n")
 }
 
-func generateIndexesStr(columns []dto.Index) string {
+func generateIndexesStr(table string, indexes []dto.Index) string {
 	var result []string
-	for _, column := range columns {
-		result = append(result, generateIndexStr(column))
+	for _, index := range indexes {
+		result = append(result, generateIndexStr(table, index))
 	}
 
-	return strings.Join(result, "\n")
+	return strings.Join(result, ";\n")
+}
+
+func generateConstraintsStr(constraints []dto.Constraint) string {
+	var result []string
+	for _, constraint := range constraints {
+		result = append(result, generateConstraintStr(constraint))
+	}
+
+	return strings.Join(result, ",\n")
+}
+
+// generateConstraintStr generates the table-level constraint, eg: CONSTRAINT name UNIQUE (col1, col2) or CONSTRAINT name CHECK (col1 > 0)
+func generateConstraintStr(constraint dto.Constraint) string {
+	str := ""
+	if constraint.Name != "" {
+		str = fmt.Sprintf("CONSTRAINT %s ", constraint.Name)
+	}
+
+	if constraint.Type == dto.CheckConstraint {
+		return str + fmt.Sprintf("%s (%s)", constraint.Type, constraint.Expression)
+	}
+
+	return str + fmt.Sprintf("%s (%s)", constraint.Type, strings.Join(constraint.Columns, ", "))
+}
+
+// prepareCreateColumns returns the columns for the CREATE TABLE statement.
+// The columns of the composite primary key are generated as the regular columns, because the key is generated as the table-level PRIMARY KEY constraint
+func prepareCreateColumns(model dto.ModelInterface) []interface{} {
+	if len(model.GetPrimaryKeys()) < 2 {
+		return model.GetColumns()
+	}
+
+	var columns []interface{}
+	for _, column := range model.GetColumns() {
+		switch v := column.(type) {
+		case dto.ModelField:
+			v.IsPrimaryKey = false
+			columns = append(columns, v)
+		}
+	}
+
+	return columns
+}
+
+// appendConstraintColumn adds the column to the last constraint, if it has the same name and type. Otherwise, the new constraint will be added
+func appendConstraintColumn(constraints []dto.Constraint, name string, constraintType string, column string) []dto.Constraint {
+	if last := len(constraints) - 1; last >= 0 && constraints[last].Name == name && constraints[last].Type == constraintType {
+		constraints[last].Columns = append(constraints[last].Columns, column)
+		return constraints
+	}
+
+	constraint := dto.Constraint{
+		Name: name,
+		Type: constraintType,
+	}
+	if column != "" {
+		constraint.Columns = []string{column}
+	}
+
+	return append(constraints, constraint)
+}
+
+func getPrimaryKeysNames(model dto.ModelInterface) (names []string) {
+	for _, key := range model.GetPrimaryKeys() {
+		names = append(names, key.Name)
+	}
+
+	return names
 }
 
 func generateForeignKey(column dto.ForeignKey) string {
@@ -348,80 +963,201 @@ func generateForeignKey(column dto.ForeignKey) string {
 		str = fmt.Sprintf("CONSTRAINT %s\n", column.Name)
 	}
 
-	str += fmt.Sprintf("FOREIGN KEY (%s)\n REFERENCES %s (%s)\n", column.With.Key, column.Target.Table, column.Target.Key)
+	//Without the key the primary key of the target table is referenced
+	target := column.Target.Table
+	if column.Target.Key != "" {
+		target += fmt.Sprintf(" (%s)", column.Target.Key)
+	}
+
+	str += fmt.Sprintf("FOREIGN KEY (%s)\n REFERENCES %s\n", column.With.Key, target)
 	str += fmt.Sprintf("ON DELETE %s\nON UPDATE %s", column.GetOnDelete(), column.GetOnUpdate())
 	return str
 }
 
-func generateIndexStr(column dto.Index) string {
+// generateIndexStr generates the CREATE INDEX statement. If the Target of the index is not specified, the selected table is used
+func generateIndexStr(table string, index dto.Index) string {
 	resultStr := "CREATE "
-	if column.Unique {
+	if index.Unique {
 		resultStr += "UNIQUE "
 	}
 
-	resultStr += fmt.Sprintf("INDEX %s \nON %s (%s);", column.Name, column.Target, column.Key)
+	resultStr += "INDEX "
+	if index.IfNotExists {
+		resultStr += "IF NOT EXISTS "
+	}
+
+	if index.Target != "" {
+		table = index.Target
+	}
+
+	resultStr += fmt.Sprintf("%s ON %s (%s)", index.Name, table, generateIndexColumnsStr(index, false))
+	if index.Where != "" {
+		resultStr += fmt.Sprintf(" WHERE %s", index.Where)
+	}
+
 	return resultStr
 }
 
+// generateIndexColumnsStr generates the list of the index columns with the directions. The expressions are wrapped into the parentheses.
+// The prefix length of the column is used only if it is supported by the database
+func generateIndexColumnsStr(index dto.Index, isPrefixLengthSupported bool) string {
+	if len(index.Columns) == 0 {
+		return index.Key
+	}
+
+	var result []string
+	for _, column := range index.Columns {
+		columnStr := column.Name
+		if column.Expression != "" {
+			columnStr = fmt.Sprintf("(%s)", column.Expression)
+		} else if isPrefixLengthSupported && column.Length > 0 {
+			columnStr += fmt.Sprintf("(%d)", column.Length)
+		}
+
+		if column.Direction != "" {
+			columnStr += fmt.Sprintf(" %s", strings.ToUpper(column.Direction))
+		}
+
+		result = append(result, columnStr)
+	}
+
+	return strings.Join(result, ", ")
+}
+
+// generateDropIndexStr generates the DROP INDEX statement. If the Name of the index is not specified, the Key is used
+func generateDropIndexStr(index dto.Index) string {
+	name := index.Name
+	if name == "" {
+		name = index.Key
+	}
+
+	if index.IfExists {
+		return fmt.Sprintf("DROP INDEX IF EXISTS %s", name)
+	}
+
+	return fmt.Sprintf("DROP INDEX %s", name)
+}
+
 func isNewSchemaShouldBeGenerated(q QueryInterface) bool {
-	if len(q.GetColumnsToDrop()) > 0 || len(q.GetForeignKeysToAdd()) > 0 || len(q.GetForeignKeysToDrop()) > 0 {
+	if len(q.GetColumnsToDrop()) > 0 || len(q.GetColumnsToRename()) > 0 || len(q.GetColumnsToModify()) > 0 ||
+		len(q.GetForeignKeysToAdd()) > 0 || len(q.GetForeignKeysToDrop()) > 0 ||
+		len(q.GetConstraintsToAdd()) > 0 || len(q.GetConstraintsToDrop()) > 0 {
 		return true
 	}
 
 	return false
 }
 
+// buildTempTableSQLiteQuery builds the CREATE query of the new table for the table rebuild. The indexes are not added here, because the indexes with the same names still exist for the old table
 func buildTempTableSQLiteQuery(q QueryInterface) QueryInterface {
-	//We remove columns which we need to drop
+	//We remove columns which we need to drop and apply the renames and the new definitions of the columns
 	var columns []interface{}
 	for _, column := range q.GetDestination().GetColumns() {
 		switch col := column.(type) {
 		case dto.ModelField:
-			if len(q.GetColumnsToDrop()) == 0 {
-				columns = append(columns, col)
+			if isColumnDropped(q, col.Name) {
 				continue
 			}
 
-			for _, columnToDrop := range q.GetColumnsToDrop() {
-				switch v := columnToDrop.(type) {
-				case dto.ModelField:
-					if col.Name == v.Name {
-						continue
-					}
-
-					columns = append(columns, col)
-				}
-			}
+			columns = append(columns, prepareAlteredColumn(q, col))
 		}
 	}
 
 	//Add columns
+	for _, column := range q.GetColumns() {
+		switch v := column.(type) {
+		case dto.ModelField:
+			columns = append(columns, v)
+		}
+	}
+
 	qb := (new(Query)).Create(&dto.BaseModel{
 		TableName:  fmt.Sprintf("%s%s", TempTablePrefix, q.GetDestination().GetTableName()),
-		PrimaryKey: q.GetDestination().GetPrimaryKey(),
+		PrimaryKey: prepareAlteredColumn(q, q.GetDestination().GetPrimaryKey()),
 		Fields:     columns,
+		Options:    q.GetDestination().GetTableOptions(),
 	})
 
+	for _, column := range q.GetForeignKeysToAdd() {
+		qb.AddForeignKey(column)
+	}
+
+	//The existing foreign keys and constraints of the table, which are not dropped, are added to the query during the execution, see SQLiteClient.prepareRebuildQuery
+	for _, constraint := range q.GetConstraintsToAdd() {
+		qb.AddConstraint(constraint)
+	}
+
+	return qb
+}
+
+// isColumnDropped checks if the column was specified by DropColumn method of the alter query
+func isColumnDropped(q QueryInterface, name string) bool {
+	for _, column := range q.GetColumnsToDrop() {
+		switch v := column.(type) {
+		case dto.ModelField:
+			if v.Name == name {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// isColumnAdded checks if the column was specified by AddColumn method of the alter query
+func isColumnAdded(q QueryInterface, name string) bool {
 	for _, column := range q.GetColumns() {
 		switch v := column.(type) {
 		case dto.ModelField:
-			qb.AddColumn(v)
+			if v.Name == name {
+				return true
+			}
 		}
 	}
 
-	for _, column := range q.GetIndexesToAdd() {
-		qb.AddIndex(column)
+	return false
+}
+
+// prepareAlteredColumn returns the column with the new name and the new definition, if they were specified by RenameColumn and ModifyColumn methods of the alter query
+func prepareAlteredColumn(q QueryInterface, column dto.ModelField) dto.ModelField {
+	if column.Name == "" {
+		return column
 	}
 
-	for _, column := range q.GetIndexesToAdd() {
-		qb.AddIndex(column)
+	for _, rename := range q.GetColumnsToRename() {
+		if rename.From == column.Name {
+			column.Name = rename.To
+			break
+		}
 	}
 
-	for _, column := range q.GetForeignKeysToAdd() {
-		qb.AddForeignKey(column)
+	if modified, ok := findModifiedColumn(q, column.Name); ok {
+		return modified
 	}
 
-	return qb
+	return column
+}
+
+// findModifiedColumn returns the new definition of the column, which was specified by ModifyColumn method of the alter query
+func findModifiedColumn(q QueryInterface, name string) (dto.ModelField, bool) {
+	for _, column := range q.GetColumnsToModify() {
+		if column.Name == name {
+			return column, true
+		}
+	}
+
+	return dto.ModelField{}, false
+}
+
+// getRenamedColumnSource returns the original name of the renamed column
+func getRenamedColumnSource(q QueryInterface, name string) string {
+	for _, rename := range q.GetColumnsToRename() {
+		if rename.To == name {
+			return rename.From
+		}
+	}
+
+	return name
 }
 
 func prepareRenameTableQuery(q QueryInterface) string {
@@ -430,9 +1166,105 @@ func prepareRenameTableQuery(q QueryInterface) string {
 
 // prepareDropQuery method prepares the drop query statement
 func prepareDropQuery(q QueryInterface) string {
+	if q.GetIfExists() {
+		return fmt.Sprintf("DROP TABLE IF EXISTS %s", q.GetDestination().GetTableName())
+	}
+
 	return fmt.Sprintf("DROP TABLE %s", q.GetDestination().GetTableName())
 }
 
+// generateCreateTableStr generates the beginning of the CREATE TABLE statement with the TEMPORARY and IF NOT EXISTS clauses
+func generateCreateTableStr(q QueryInterface) string {
+	queryStr := "CREATE "
+	if q.GetTemporary() {
+		queryStr += "TEMPORARY "
+	}
+
+	queryStr += "TABLE "
+	if q.GetIfNotExists() {
+		queryStr += "IF NOT EXISTS "
+	}
+
+	return queryStr + q.GetDestination().GetTableName()
+}
+
+// prepareDropViewQuery method prepares the drop view statement
+func prepareDropViewQuery(q QueryInterface) string {
+	if q.GetIfExists() {
+		return fmt.Sprintf("DROP VIEW IF EXISTS %s", q.GetDestination().GetTableName())
+	}
+
+	return fmt.Sprintf("DROP VIEW %s", q.GetDestination().GetTableName())
+}
+
+// prepareDropTriggerQuery method prepares the drop trigger statement
+func prepareDropTriggerQuery(q QueryInterface) string {
+	if q.GetIfExists() {
+		return fmt.Sprintf("DROP TRIGGER IF EXISTS %s", q.GetTrigger().Name)
+	}
+
+	return fmt.Sprintf("DROP TRIGGER %s", q.GetTrigger().Name)
+}
+
+// generateTriggerStr generates the trigger definition without the body: name BEFORE INSERT ON table
+func generateTriggerStr(trigger dto.Trigger) string {
+	return fmt.Sprintf("%s %s %s ON %s", trigger.Name, strings.ToUpper(trigger.Timing), strings.ToUpper(trigger.Event), trigger.Table)
+}
+
+// generateTriggerBodyStr generates the BEGIN ... END block of the trigger. Each statement of the block should be terminated by the semicolon
+func generateTriggerBodyStr(body string) string {
+	return fmt.Sprintf("BEGIN %s; END", strings.TrimSuffix(strings.TrimSpace(body), ";"))
+}
+
+// parseTriggerBody returns the statements of the BEGIN ... END block of the trigger
+func parseTriggerBody(body string) string {
+	if matches := triggerBodyRegexp.FindStringSubmatch(strings.TrimSpace(body)); matches != nil {
+		return strings.TrimSuffix(strings.TrimSpace(matches[1]), ";")
+	}
+
+	return body
+}
+
+// validateViewQuery checks if the view can be created. The definition of the view cannot contain the placeholders, so the bindings cannot be used in the select query of the view
+func validateViewQuery(q QueryInterface) error {
+	if q.GetQueryType() != CreateViewType {
+		return nil
+	}
+
+	if q.GetViewQuery() == nil || q.GetViewQuery().GetQueryType() != SelectType {
+		return errors.New("The view should be created from the select query ")
+	}
+
+	if len(q.GetViewQuery().GetBindings()) > 0 {
+		return errors.New("The select query of the view cannot contain the bindings, please use the raw expressions without the bindings ")
+	}
+
+	return nil
+}
+
+// validatePrimaryKeyValues checks if the number of the values specified by Find method is the same as the number of the primary key columns of the model
+func validatePrimaryKeyValues(q QueryInterface) error {
+	if q.GetPrimaryKeyValues() == nil || q.GetDestination() == nil {
+		return nil
+	}
+
+	if keys := q.GetDestination().GetPrimaryKeys(); len(keys) != len(q.GetPrimaryKeyValues()) {
+		return fmt.Errorf("The model %s has %d primary key columns, but %d values are specified ", q.GetDestination().GetTableName(), len(keys), len(q.GetPrimaryKeyValues()))
+	}
+
+	return nil
+}
+
+// validateLimit checks if the LIMIT of the query is specified using From and To fields or using Offset and Count fields, but not both of them
+func validateLimit(q QueryInterface) error {
+	limit := q.GetLimit()
+	if (limit.From != 0 || limit.To != 0) && (limit.Offset != 0 || limit.Count != 0) {
+		return errors.New("The LIMIT should be specified using From and To or using Offset and Count fields, they cannot be mixed ")
+	}
+
+	return nil
+}
+
 func prepareColumnTypes(rows *sql.Rows) (result []string, err error) {
 	columnTypes, err := rows.ColumnTypes()
 	if err != nil {
@@ -504,7 +1336,27 @@ func toSQLValue(value interface{}) string {
 		resultStr += fmt.Sprintf(`"%s"`, v)
 	case bool:
 		resultStr += fmt.Sprintf("%t", v)
+	case dto.RawExpression:
+		resultStr += string(v)
 	}
 
 	return resultStr
 }
+
+// isVersionAtLeast checks if the database server version is equal or higher than the minimal version
+func isVersionAtLeast(version string, minimal []int) bool {
+	//The version can contain suffix, eg: 8.0.31-log or 10.6.12-MariaDB
+	parts := strings.Split(strings.SplitN(version, "-", 2)[0], ".")
+	for i, expected := range minimal {
+		var actual int
+		if i < len(parts) {
+			actual, _ = strconv.Atoi(parts[i])
+		}
+
+		if actual != expected {
+			return actual > expected
+		}
+	}
+
+	return true
+}
@@ -1,19 +1,24 @@
 package clients
 
 import (
+	"context"
 	"database/sql"
 	"errors"
 	"fmt"
+	"regexp"
 	"strings"
 
 	_ "github.com/go-sql-driver/mysql"
 	"github.com/sharovik/orm/dto"
+	"github.com/sharovik/orm/query"
 )
 
 // MySQLClient the SQLite client
 type MySQLClient struct {
-	Client *sql.DB
-	Config DatabaseConfig
+	Client  *sql.DB
+	Config  DatabaseConfig
+	conn    *sql.Conn
+	version string
 }
 
 func (c MySQLClient) Connect(config DatabaseConfig) (client BaseClientInterface, err error) {
@@ -63,6 +68,10 @@ func (c MySQLClient) generateHost() string {
 }
 
 func (c MySQLClient) Disconnect() error {
+	if c.conn != nil {
+		return c.conn.Close()
+	}
+
 	return c.Client.Close()
 }
 
@@ -70,38 +79,108 @@ func (c MySQLClient) GetClient() *sql.DB {
 	return c.Client
 }
 
+// Session returns the client, which executes all queries using the same connection of the pool. The Disconnect method of the session returns the connection to the pool
+func (c MySQLClient) Session(ctx context.Context) (client BaseClientInterface, err error) {
+	if c.conn != nil {
+		return c, errors.New("The session is already started for this client ")
+	}
+
+	if c.conn, err = c.GetClient().Conn(ctx); err != nil {
+		return c, err
+	}
+
+	return c, nil
+}
+
+// executor returns the connection of the session or the pool of the connections
+func (c MySQLClient) executor() executor {
+	if c.conn != nil {
+		return c.conn
+	}
+
+	return c.Client
+}
+
 func (c MySQLClient) ToSql(q QueryInterface) string {
 	return toSql(c, q)
 }
 
 func (c MySQLClient) Execute(q QueryInterface) (result dto.BaseResult, err error) {
+	return c.ExecuteContext(context.Background(), q)
+}
+
+// Page executes the select query for selected page and the COUNT(*) query for the total number of rows
+func (c MySQLClient) Page(ctx context.Context, q QueryInterface, page int64, perPage int64) (result dto.PageResult, err error) {
+	return executePage(ctx, c, q, page, perPage)
+}
+
+// ExecuteContext executes the query using the context
+func (c MySQLClient) ExecuteContext(ctx context.Context, q QueryInterface) (result dto.BaseResult, err error) {
+	if isEmptyUpdateQuery(q) {
+		return result, nil
+	}
+
+	//The locking clause FOR SHARE depends on the server version, so it should be known before the query is generated
+	if q.GetLock().Strength == query.ForShareLock && c.version == "" {
+		if c.version, err = c.serverVersion(); err != nil {
+			return result, err
+		}
+	}
+
+	//The IF EXISTS clauses of the indexes and foreign keys are generated only for MariaDB, so the server version should be known before the query is generated
+	if hasIndexIfExists(q) {
+		if c.version, err = c.serverVersion(); err != nil {
+			return result, err
+		}
+
+		if q, err = c.emulateIndexIfExists(ctx, q); err != nil {
+			return result, err
+		}
+	}
+
 	var queryStr = c.ToSql(q)
 	if queryStr == "" {
 		return result, errors.New("Query string cannot be empty ")
 	}
 
+	if err = c.validateQuery(q); err != nil {
+		return result, err
+	}
+
 	var bindings []interface{}
-	for _, bind := range q.GetBindings() {
+	for _, bind := range c.prepareBindings(q) {
 		bindings = append(bindings, bind.Value)
 	}
 
 	switch q.GetQueryType() {
 	case SelectType:
-		result, err = c.executeSelect(queryStr, bindings)
+		result, err = c.executeSelect(ctx, queryStr, bindings, q.GetDestination())
 	case CreateType:
-		result, err = c.executeQuery(queryStr, bindings)
+		result, err = c.executeQuery(ctx, queryStr, bindings)
 	case AlterType:
-		result, err = c.executeQuery(queryStr, bindings)
+		result, err = c.executeQuery(ctx, queryStr, bindings)
 	case RenameType:
-		result, err = c.executeQuery(queryStr, bindings)
+		result, err = c.executeQuery(ctx, queryStr, bindings)
 	case DeleteType:
-		result, err = c.executeQuery(queryStr, bindings)
+		result, err = c.executeQuery(ctx, queryStr, bindings)
 	case DropType:
-		result, err = c.executeQuery(queryStr, bindings)
+		result, err = c.executeQuery(ctx, queryStr, bindings)
+	case TruncateType:
+		result, err = c.executeQuery(ctx, queryStr, bindings)
+	case CreateViewType:
+		result, err = c.executeQuery(ctx, queryStr, bindings)
+	case DropViewType:
+		result, err = c.executeQuery(ctx, queryStr, bindings)
+	case CreateTriggerType:
+		result, err = c.executeQuery(ctx, queryStr, bindings)
+	case DropTriggerType:
+		result, err = c.executeQuery(ctx, queryStr, bindings)
 	case InsertType:
-		result, err = c.executeQuery(queryStr, bindings)
+		result, err = c.executeQuery(ctx, queryStr, bindings)
 	case UpdateType:
-		result, err = c.executeQuery(queryStr, bindings)
+		result, err = c.executeQuery(ctx, queryStr, bindings)
+	case TransactionBegin, TransactionCommit, TransactionRollback:
+		result, err = c.executeQuery(ctx, queryStr, bindings)
 	}
 
 	if err != nil {
@@ -111,13 +190,15 @@ func (c MySQLClient) Execute(q QueryInterface) (result dto.BaseResult, err error
 	return result, nil
 }
 
-func (c MySQLClient) executeSelect(queryStr string, bindings []interface{}) (result dto.BaseResult, err error) {
-	rows, err := c.GetClient().Query(queryStr, bindings...)
+func (c MySQLClient) executeSelect(ctx context.Context, queryStr string, bindings []interface{}, destination dto.ModelInterface) (result dto.BaseResult, err error) {
+	rows, err := c.executor().QueryContext(ctx, queryStr, bindings...)
 	if err != nil {
 		result.SetError(err)
 		return result, err
 	}
 
+	defer rows.Close()
+
 	columns, err := rows.Columns()
 	if err != nil {
 		result.SetError(err)
@@ -153,14 +234,16 @@ func (c MySQLClient) executeSelect(queryStr string, bindings []interface{}) (res
 			})
 		}
 
+		setResultModelDestination(model, destination)
+		model.SyncOriginal()
 		result.AddItem(model)
 	}
 
 	return result, nil
 }
 
-func (c MySQLClient) executeQuery(queryStr string, bindings []interface{}) (result dto.BaseResult, err error) {
-	rows, err := c.GetClient().Exec(queryStr, bindings...)
+func (c MySQLClient) executeQuery(ctx context.Context, queryStr string, bindings []interface{}) (result dto.BaseResult, err error) {
+	rows, err := c.executor().ExecContext(ctx, queryStr, bindings...)
 	if err != nil {
 		result.SetError(err)
 		return result, err
@@ -187,16 +270,639 @@ func (c MySQLClient) prepareTransactionRollback() string {
 	return "ROLLBACK;"
 }
 
+// validateQuery method checks if the query can be executed by the MySQL server
+func (c MySQLClient) validateQuery(q QueryInterface) error {
+	if err := validateViewQuery(q); err != nil {
+		return err
+	}
+
+	if err := validatePrimaryKeyValues(q); err != nil {
+		return err
+	}
+
+	if err := validateLimit(q); err != nil {
+		return err
+	}
+
+	if q.GetQueryType() == CreateTriggerType && strings.EqualFold(q.GetTrigger().Timing, dto.InsteadOfTriggerTiming) {
+		return errors.New("INSTEAD OF triggers are not supported by MySQL database ")
+	}
+
+	if err := validateFullJoins(getMySQLSelectQuery(q)); err != nil {
+		return err
+	}
+
+	if (q.GetQueryType() == UpdateType || q.GetQueryType() == DeleteType) && len(q.GetJoins()) > 0 &&
+		(len(q.GetGroupBy()) > 0 || len(q.GetOrderBy()) > 0 || q.GetLimit() != *new(query.Limit)) {
+		return errors.New("GROUP BY, ORDER BY and LIMIT cannot be used in the multiple-table UPDATE and DELETE statements for MySQL database ")
+	}
+
+	if q.GetLock() != *new(query.Lock) && (len(q.GetCompounds()) > 0 || countJoins(q.GetJoins(), query.FullJoinType) > 0) {
+		return errors.New("The locking clause cannot be used with UNION, INTERSECT, EXCEPT or FULL OUTER JOIN for MySQL database ")
+	}
+
+	for _, index := range append(q.GetIndexesToAdd(), q.GetIndexesToDrop()...) {
+		if index.Where != "" {
+			return errors.New("Partial indexes are not supported by MySQL database ")
+		}
+	}
+
+	//The renamed columns without the new definition are renamed using RENAME COLUMN clause
+	var isRenameColumn bool
+	for _, rename := range q.GetColumnsToRename() {
+		if _, ok := findModifiedColumn(q, rename.To); !ok {
+			isRenameColumn = true
+		}
+	}
+
+	var (
+		isIntersectExcept = hasCompound(q, IntersectCompound, ExceptCompound)
+		isWindow          = hasWindowFunctions(q)
+		isLock            = q.GetLock().Strength == query.ForShareLock || q.GetLock().Option != ""
+	)
+	if !isIntersectExcept && !isWindow && !isLock && !isRenameColumn {
+		return nil
+	}
+
+	version, err := c.serverVersion()
+	if err != nil {
+		return err
+	}
+
+	if isIntersectExcept && !isIntersectExceptSupported(version) {
+		return fmt.Errorf("INTERSECT and EXCEPT are not supported by the MySQL server version %s. These operations are available since MySQL 8.0.31 and MariaDB 10.3 ", version)
+	}
+
+	if isWindow && !isWindowFunctionsSupported(version) {
+		return fmt.Errorf("Window functions are not supported by the MySQL server version %s. These functions are available since MySQL 8.0 and MariaDB 10.2 ", version)
+	}
+
+	if isLock && !isLockSupported(version, q.GetLock()) {
+		return fmt.Errorf("The locking clause %s is not supported by the MySQL server version %s. FOR SHARE, NOWAIT and SKIP LOCKED are available since MySQL 8.0, MariaDB supports LOCK IN SHARE MODE instead of FOR SHARE, NOWAIT since 10.3 and SKIP LOCKED since 10.6 ", generateLockStr(q.GetLock()), version)
+	}
+
+	if isRenameColumn && !isRenameColumnSupported(version) {
+		return fmt.Errorf("RENAME COLUMN clause is not supported by the MySQL server version %s. It is available since MySQL 8.0.3 and MariaDB 10.5.2, for the older versions please use ModifyColumn with the new column name to generate the CHANGE clause ", version)
+	}
+
+	return nil
+}
+
+// validateFullJoins checks if the FULL OUTER JOIN of the query can be emulated. Only the FULL OUTER JOIN of the select query itself is emulated, so it cannot be used in the nested queries
+func validateFullJoins(q QueryInterface) error {
+	if fullJoins := countJoins(q.GetJoins(), query.FullJoinType); fullJoins > 0 {
+		if fullJoins > 1 {
+			return errors.New("Only one FULL OUTER JOIN can be used in the query for MySQL database ")
+		}
+
+		if len(q.GetGroupBy()) > 0 {
+			return errors.New("FULL OUTER JOIN cannot be used with GROUP BY clause for MySQL database ")
+		}
+
+		if _, ok := q.(*Query); !ok {
+			return errors.New("FULL OUTER JOIN can be emulated only for the query created by clients.Query for MySQL database ")
+		}
+
+		for _, join := range q.GetJoins() {
+			if strings.EqualFold(join.Type, query.FullJoinType) && getFullJoinNullColumn(join) == "" {
+				return errors.New("FULL OUTER JOIN should have the Target.Key and With.Key or the Using columns with the With.Table for MySQL database, because the rows of the joined table without the matched rows are selected by the NULL value of this column ")
+			}
+		}
+	}
+
+	if hasNestedJoins(q, query.FullJoinType) {
+		return errors.New("FULL OUTER JOIN cannot be used in the subqueries, common table expressions and compound queries for MySQL database ")
+	}
+
+	return nil
+}
+
+// getMySQLSelectQuery returns the select query, which is generated by prepareSelectQuery method of the client. For CREATE TABLE ... AS SELECT and CREATE VIEW statements it is the nested select query
+func getMySQLSelectQuery(q QueryInterface) QueryInterface {
+	switch q.GetQueryType() {
+	case CreateViewType:
+		if q.GetViewQuery() != nil {
+			return q.GetViewQuery()
+		}
+	case CreateType:
+		switch v := q.GetValues().(type) {
+		case QueryInterface:
+			return v
+		}
+	}
+
+	return q
+}
+
+// prepareBindings method returns the bindings in the order of the placeholders of the generated query
+func (c MySQLClient) prepareBindings(q QueryInterface) []query.Bind {
+	if selectQuery := getMySQLSelectQuery(q); selectQuery != q {
+		return c.prepareBindings(selectQuery)
+	}
+
+	if q.GetQueryType() == UpdateType && len(q.GetJoins()) > 0 {
+		//In the multiple-table UPDATE statement the SET clause is generated after the JOIN clauses
+		var (
+			bindings = q.GetBindings()
+			setStart = countWithBindings(q)
+			setEnd   = setStart + countAssignmentsBindings(q.GetColumns())
+			joinEnd  = setEnd + len(q.GetJoinBindings())
+			result   []query.Bind
+		)
+		result = append(result, bindings[:setStart]...)
+		result = append(result, bindings[setEnd:joinEnd]...)
+		result = append(result, bindings[setStart:setEnd]...)
+		result = append(result, bindings[joinEnd:]...)
+
+		return result
+	}
+
+	//For the emulated FULL OUTER JOIN the select statement is used twice, so its bindings are repeated by the compound query
+	return expandFullJoin(q).GetBindings()
+}
+
+// prepareUpdateQuery method prepares the update query statement. For the joins the multiple-table syntax is used: UPDATE table JOIN ... SET ...
+func (c MySQLClient) prepareUpdateQuery(q QueryInterface) string {
+	if len(q.GetJoins()) == 0 {
+		return prepareUpdateQuery(q)
+	}
+
+	queryStr := generateWithStr(q)
+	queryStr += fmt.Sprintf("UPDATE %s %s SET %s", q.GetDestination().GetTableName(), generateJoinsStr(q.GetJoins()), generateAssignmentsStr(q.GetColumns()))
+
+	if len(q.GetWheres()) > 0 {
+		queryStr += fmt.Sprintf(" %s", generateWhereStr(q.GetWheres()))
+	}
+
+	return queryStr
+}
+
+// prepareDeleteQuery method prepares the delete query statement. For the joins the multiple-table syntax is used: DELETE table FROM table JOIN ...
+func (c MySQLClient) prepareDeleteQuery(q QueryInterface) string {
+	if len(q.GetJoins()) == 0 {
+		return prepareDeleteQuery(q)
+	}
+
+	queryStr := generateWithStr(q)
+	queryStr += fmt.Sprintf("DELETE %s FROM %s %s", q.GetDestination().GetTableName(), q.GetDestination().GetTableName(), generateJoinsStr(q.GetJoins()))
+
+	if len(q.GetWheres()) > 0 {
+		queryStr += fmt.Sprintf(" %s", generateWhereStr(q.GetWheres()))
+	}
+
+	return queryStr
+}
+
+// prepareSelectQuery method prepares the select query statement.
+// MySQL does not support FULL OUTER JOIN, so it is emulated as the union of the LEFT JOIN and RIGHT JOIN select statements
+func (c MySQLClient) prepareSelectQuery(q QueryInterface) string {
+	if countJoins(q.GetJoins(), query.FullJoinType) == 0 {
+		if q.GetLock() == *new(query.Lock) {
+			return prepareSelectQuery(q)
+		}
+
+		return fmt.Sprintf("%s %s", prepareSelectQuery(q), generateMySQLLockStr(c.version, q.GetLock()))
+	}
+
+	return prepareSelectQuery(expandFullJoin(q))
+}
+
+// prepareCountQuery method prepares the COUNT(*) query for the pagination. The query with the FULL OUTER JOIN is emulated before, so it is counted as the compound query using the subquery
+func (c MySQLClient) prepareCountQuery(q QueryInterface) QueryInterface {
+	return prepareCountQuery(expandFullJoin(q))
+}
+
+// expandFullJoin returns the query, where the FULL OUTER JOIN is replaced by the LEFT JOIN and the same select statement with the RIGHT JOIN is added as the first UNION ALL compound query.
+// The RIGHT JOIN query selects only the rows of the joined table without the matched rows, so the duplicate rows of the result are kept. For the DISTINCT query the UNION is used.
+// The compound queries, ORDER BY and LIMIT clauses of the original query are applied to the combined result. The query, which cannot be emulated, is rejected by validateFullJoins
+func expandFullJoin(q QueryInterface) QueryInterface {
+	original, ok := q.(*Query)
+	if !ok || countJoins(q.GetJoins(), query.FullJoinType) == 0 {
+		return q
+	}
+
+	right := original.Clone().(*Query)
+	right.joins = replaceJoinsType(original.joins, query.FullJoinType, query.RightJoinType)
+	right.with, right.withBindings = nil, nil
+	right.compounds, right.compoundBindings = nil, nil
+	right.orderBys, right.limit = nil, query.Limit{}
+
+	//The existing conditions are grouped, so the conditions with OR logical operator don't change the meaning of the anti-join condition
+	if len(original.wheres) > 1 {
+		right.wheres = []query.Where{{First: query.Group(original.wheres)}}
+	}
+
+	for _, join := range original.joins {
+		if strings.EqualFold(join.Type, query.FullJoinType) {
+			right.wheres = append(right.wheres, query.IsNull(getFullJoinNullColumn(join)))
+		}
+	}
+
+	var compoundType = UnionAllCompound
+	if original.distinct {
+		compoundType = UnionCompound
+	}
+
+	left := original.Clone().(*Query)
+	left.joins = replaceJoinsType(original.joins, query.FullJoinType, query.LeftJoinType)
+	left.compounds, left.compoundBindings = nil, nil
+	left.compound(compoundType, right)
+	for _, compound := range original.compounds {
+		left.compound(compound.Type, compound.Query)
+	}
+
+	return left
+}
+
+// getFullJoinNullColumn returns the column of the joined table from the With reference. This column is NULL only for the rows of the RIGHT JOIN, which don't have the matched rows
+func getFullJoinNullColumn(join query.Join) string {
+	switch {
+	case join.Target.Key != "" && join.With.Key != "" && join.With.Table != "":
+		return fmt.Sprintf("%s.%s", join.With.Table, join.With.Key)
+	case len(join.Using) > 0 && join.With.Table != "":
+		return fmt.Sprintf("%s.%s", join.With.Table, join.Using[0])
+	}
+
+	return ""
+}
+
+// hasIndexIfExists checks if the IF NOT EXISTS or IF EXISTS clauses are used for the indexes or foreign keys of the ALTER TABLE query.
+// The indexes of CREATE TABLE statement are the part of the table definition, so the flags are ignored there
+func hasIndexIfExists(q QueryInterface) bool {
+	if q.GetQueryType() != AlterType {
+		return false
+	}
+
+	for _, index := range q.GetIndexesToAdd() {
+		if index.IfNotExists {
+			return true
+		}
+	}
+
+	for _, index := range q.GetIndexesToDrop() {
+		if index.IfExists {
+			return true
+		}
+	}
+
+	for _, foreignKey := range q.GetForeignKeysToDrop() {
+		if foreignKey.IfExists {
+			return true
+		}
+	}
+
+	return false
+}
+
+// emulateIndexIfExists emulates the IF NOT EXISTS and IF EXISTS clauses of the indexes and foreign keys for MySQL, because these clauses are available only in MariaDB.
+// The existence of the indexes and foreign keys is checked using the information_schema database
+func (c MySQLClient) emulateIndexIfExists(ctx context.Context, q QueryInterface) (QueryInterface, error) {
+	version, err := c.serverVersion()
+	if err != nil {
+		return q, err
+	}
+
+	if strings.Contains(strings.ToLower(version), "mariadb") {
+		return q, nil
+	}
+
+	return filterIndexIfExists(q, func(isForeignKey bool, name string) (isExists bool, err error) {
+		queryStr := "SELECT COUNT(*) > 0 FROM information_schema.STATISTICS WHERE TABLE_SCHEMA = DATABASE() AND TABLE_NAME = ? AND INDEX_NAME = ?"
+		if isForeignKey {
+			queryStr = "SELECT COUNT(*) > 0 FROM information_schema.TABLE_CONSTRAINTS WHERE TABLE_SCHEMA = DATABASE() AND TABLE_NAME = ? AND CONSTRAINT_NAME = ? AND CONSTRAINT_TYPE = 'FOREIGN KEY'"
+		}
+
+		err = c.executor().QueryRowContext(ctx, queryStr, q.GetDestination().GetTableName(), name).Scan(&isExists)
+		return isExists, err
+	})
+}
+
+// filterIndexIfExists removes the existing indexes with IF NOT EXISTS clause and the missing indexes and foreign keys with IF EXISTS clause from the ALTER TABLE query.
+// The clauses of the rest indexes and foreign keys are removed
+func filterIndexIfExists(q QueryInterface, isExists func(isForeignKey bool, name string) (bool, error)) (QueryInterface, error) {
+	original, ok := q.(*Query)
+	if !ok {
+		return q, nil
+	}
+
+	result := original.Clone().(*Query)
+	result.indexAdd, result.indexDrop, result.foreignKeysDrop = nil, nil, nil
+	for _, index := range original.indexAdd {
+		//The name of the index is generated by the database, if it is not specified
+		if index.IfNotExists && index.Name != "" {
+			exists, err := isExists(false, index.Name)
+			if err != nil {
+				return q, err
+			}
+
+			if exists {
+				continue
+			}
+		}
+
+		index.IfNotExists = false
+		result.indexAdd = append(result.indexAdd, index)
+	}
+
+	for _, index := range original.indexDrop {
+		if index.IfExists {
+			name := index.Name
+			if name == "" {
+				name = index.Key
+			}
+
+			exists, err := isExists(false, name)
+			if err != nil {
+				return q, err
+			}
+
+			if !exists {
+				continue
+			}
+		}
+
+		index.IfExists = false
+		result.indexDrop = append(result.indexDrop, index)
+	}
+
+	for _, foreignKey := range original.foreignKeysDrop {
+		if foreignKey.IfExists {
+			exists, err := isExists(true, foreignKey.Name)
+			if err != nil {
+				return q, err
+			}
+
+			if !exists {
+				continue
+			}
+		}
+
+		foreignKey.IfExists = false
+		result.foreignKeysDrop = append(result.foreignKeysDrop, foreignKey)
+	}
+
+	return result, nil
+}
+
+// Constraints returns the PRIMARY KEY, UNIQUE and CHECK constraints of the table from the information_schema database
+func (c MySQLClient) Constraints(ctx context.Context, table string) (constraints []dto.Constraint, err error) {
+	rows, err := c.executor().QueryContext(ctx, `SELECT tc.CONSTRAINT_NAME, tc.CONSTRAINT_TYPE, COALESCE(kcu.COLUMN_NAME, '')
+FROM information_schema.TABLE_CONSTRAINTS tc
+LEFT JOIN information_schema.KEY_COLUMN_USAGE kcu ON kcu.CONSTRAINT_SCHEMA = tc.CONSTRAINT_SCHEMA AND kcu.TABLE_NAME = tc.TABLE_NAME AND kcu.CONSTRAINT_NAME = tc.CONSTRAINT_NAME
+WHERE tc.TABLE_SCHEMA = DATABASE() AND tc.TABLE_NAME = ? AND tc.CONSTRAINT_TYPE IN ('PRIMARY KEY', 'UNIQUE', 'CHECK')
+ORDER BY FIELD(tc.CONSTRAINT_TYPE, 'PRIMARY KEY', 'UNIQUE', 'CHECK'), tc.CONSTRAINT_NAME, kcu.ORDINAL_POSITION`, table)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	var checks []interface{}
+	for rows.Next() {
+		var name, constraintType, column string
+		if err = rows.Scan(&name, &constraintType, &column); err != nil {
+			return nil, err
+		}
+
+		if constraintType == dto.CheckConstraint {
+			checks = append(checks, name)
+		}
+
+		constraints = appendConstraintColumn(constraints, name, constraintType, column)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	//The CHECK constraints are available since MySQL 8.0.16 and MariaDB 10.2, so their expressions are selected only if they exist
+	if len(checks) == 0 {
+		return constraints, nil
+	}
+
+	checkRows, err := c.executor().QueryContext(ctx, fmt.Sprintf(`SELECT CONSTRAINT_NAME, CHECK_CLAUSE FROM information_schema.CHECK_CONSTRAINTS
+WHERE CONSTRAINT_SCHEMA = DATABASE() AND CONSTRAINT_NAME IN (%s)`, strings.TrimSuffix(strings.Repeat("?, ", len(checks)), ", ")), checks...)
+	if err != nil {
+		return nil, err
+	}
+
+	defer checkRows.Close()
+
+	for checkRows.Next() {
+		var name, expression string
+		if err = checkRows.Scan(&name, &expression); err != nil {
+			return nil, err
+		}
+
+		for i, constraint := range constraints {
+			if constraint.Type == dto.CheckConstraint && constraint.Name == name {
+				constraints[i].Expression = expression
+			}
+		}
+	}
+
+	return constraints, checkRows.Err()
+}
+
+// Columns returns the columns of the table from the information_schema database
+func (c MySQLClient) Columns(ctx context.Context, table string) (columns []dto.ModelField, err error) {
+	rows, err := c.executor().QueryContext(ctx, `SELECT COLUMN_NAME, DATA_TYPE, COLUMN_TYPE, COALESCE(CHARACTER_MAXIMUM_LENGTH, 0), IS_NULLABLE, COLUMN_DEFAULT, COLUMN_KEY, EXTRA,
+COALESCE(GENERATION_EXPRESSION, ''), COALESCE(CHARACTER_SET_NAME, ''), COALESCE(COLLATION_NAME, ''), COLUMN_COMMENT
+FROM information_schema.COLUMNS
+WHERE TABLE_SCHEMA = DATABASE() AND TABLE_NAME = ? ORDER BY ORDINAL_POSITION`, table)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	for rows.Next() {
+		var (
+			column                             dto.ModelField
+			columnType, isNullable, key, extra string
+			defaultValue                       sql.NullString
+		)
+		if err = rows.Scan(&column.Name, &column.Type, &columnType, &column.Length, &isNullable, &defaultValue, &key, &extra,
+			&column.Generated, &column.Charset, &column.Collate, &column.Comment); err != nil {
+			return nil, err
+		}
+
+		column.Type = strings.ToUpper(column.Type)
+		column.IsNullable = isNullable == "YES"
+		column.IsPrimaryKey = key == "PRI"
+		column.IsUnsigned = strings.Contains(strings.ToLower(columnType), "unsigned")
+		if !strings.Contains(strings.ToLower(column.Type), "char") && !strings.Contains(strings.ToLower(column.Type), "binary") {
+			column.Length = 0
+		}
+
+		if defaultValue.Valid {
+			column.Default = parseMySQLColumnDefault(defaultValue.String, extra)
+		}
+
+		columns = append(columns, parseMySQLColumnExtra(column, extra))
+	}
+
+	return columns, rows.Err()
+}
+
+// TableOptions returns the options of the table from the information_schema database
+func (c MySQLClient) TableOptions(ctx context.Context, table string) (options dto.TableOptions, err error) {
+	err = c.executor().QueryRowContext(ctx, `SELECT COALESCE(t.ENGINE, ''), COALESCE(ccsa.CHARACTER_SET_NAME, ''), COALESCE(t.TABLE_COLLATION, ''), t.TABLE_COMMENT, COALESCE(t.AUTO_INCREMENT, 0)
+FROM information_schema.TABLES t
+LEFT JOIN information_schema.COLLATION_CHARACTER_SET_APPLICABILITY ccsa ON ccsa.COLLATION_NAME = t.TABLE_COLLATION
+WHERE t.TABLE_SCHEMA = DATABASE() AND t.TABLE_NAME = ?`, table).Scan(&options.Engine, &options.Charset, &options.Collate, &options.Comment, &options.AutoIncrement)
+	if errors.Is(err, sql.ErrNoRows) {
+		return options, fmt.Errorf("The table %s does not exist ", table)
+	}
+
+	return options, err
+}
+
+var mysqlOnUpdateRegexp = regexp.MustCompile(`(?i)\bon update (.+)$`)
+
+// parseMySQLColumnDefault returns the default value of the column. The expressions and the quoted literals of MariaDB are returned as dto.RawExpression, so they can be used in the column definition as they are
+func parseMySQLColumnDefault(value string, extra string) interface{} {
+	if strings.Contains(extra, "DEFAULT_GENERATED") || strings.HasPrefix(value, "'") || strings.HasPrefix(strings.ToLower(value), "current_timestamp") {
+		return dto.RawExpression(value)
+	}
+
+	return value
+}
+
+// parseMySQLColumnExtra sets the AUTO_INCREMENT, ON UPDATE and the type of the generated column from the extra information of the column
+func parseMySQLColumnExtra(column dto.ModelField, extra string) dto.ModelField {
+	column.AutoIncrement = strings.Contains(strings.ToLower(extra), "auto_increment")
+	if matches := mysqlOnUpdateRegexp.FindStringSubmatch(extra); matches != nil {
+		column.OnUpdate = matches[1]
+	}
+
+	switch {
+	case column.Generated == "":
+	case strings.Contains(strings.ToUpper(extra), dto.VirtualGeneratedType):
+		column.GeneratedType = dto.VirtualGeneratedType
+	default:
+		//MariaDB uses PERSISTENT keyword for the stored generated columns
+		column.GeneratedType = dto.StoredGeneratedType
+	}
+
+	return column
+}
+
+// Views returns the views of the current database from the information_schema database
+func (c MySQLClient) Views(ctx context.Context) (views []dto.View, err error) {
+	rows, err := c.executor().QueryContext(ctx, "SELECT TABLE_NAME, VIEW_DEFINITION FROM information_schema.VIEWS WHERE TABLE_SCHEMA = DATABASE() ORDER BY TABLE_NAME")
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	for rows.Next() {
+		var view dto.View
+		if err = rows.Scan(&view.Name, &view.Definition); err != nil {
+			return nil, err
+		}
+
+		views = append(views, view)
+	}
+
+	return views, rows.Err()
+}
+
+// Triggers returns the triggers of the table from the information_schema database
+func (c MySQLClient) Triggers(ctx context.Context, table string) (triggers []dto.Trigger, err error) {
+	rows, err := c.executor().QueryContext(ctx, `SELECT TRIGGER_NAME, EVENT_OBJECT_TABLE, ACTION_TIMING, EVENT_MANIPULATION, ACTION_STATEMENT FROM information_schema.TRIGGERS
+WHERE TRIGGER_SCHEMA = DATABASE() AND EVENT_OBJECT_TABLE = ? ORDER BY TRIGGER_NAME`, table)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	for rows.Next() {
+		var trigger dto.Trigger
+		if err = rows.Scan(&trigger.Name, &trigger.Table, &trigger.Timing, &trigger.Event, &trigger.Body); err != nil {
+			return nil, err
+		}
+
+		trigger.Body = parseTriggerBody(trigger.Body)
+		triggers = append(triggers, trigger)
+	}
+
+	return triggers, rows.Err()
+}
+
+func (c MySQLClient) serverVersion() (version string, err error) {
+	if c.version != "" {
+		return c.version, nil
+	}
+
+	err = c.executor().QueryRowContext(context.Background(), "SELECT VERSION()").Scan(&version)
+	return version, err
+}
+
+// isIntersectExceptSupported checks if the MySQL server version supports INTERSECT and EXCEPT set operations
+func isIntersectExceptSupported(version string) bool {
+	if strings.Contains(strings.ToLower(version), "mariadb") {
+		return isVersionAtLeast(version, []int{10, 3, 0})
+	}
+
+	return isVersionAtLeast(version, []int{8, 0, 31})
+}
+
+// isWindowFunctionsSupported checks if the MySQL server version supports window functions
+func isWindowFunctionsSupported(version string) bool {
+	if strings.Contains(strings.ToLower(version), "mariadb") {
+		return isVersionAtLeast(version, []int{10, 2, 0})
+	}
+
+	return isVersionAtLeast(version, []int{8, 0, 0})
+}
+
+// isRenameColumnSupported checks if the MySQL server version supports RENAME COLUMN clause of the ALTER TABLE statement
+func isRenameColumnSupported(version string) bool {
+	if strings.Contains(strings.ToLower(version), "mariadb") {
+		return isVersionAtLeast(version, []int{10, 5, 2})
+	}
+
+	return isVersionAtLeast(version, []int{8, 0, 3})
+}
+
+// isLockSupported checks if the MySQL server version supports selected locking clause
+func isLockSupported(version string, lock query.Lock) bool {
+	if !strings.Contains(strings.ToLower(version), "mariadb") {
+		return lock == query.Lock{Strength: query.ForUpdateLock} || isVersionAtLeast(version, []int{8, 0, 0})
+	}
+
+	switch lock.Option {
+	case query.NoWaitLockOption:
+		return isVersionAtLeast(version, []int{10, 3, 0})
+	case query.SkipLockedLockOption:
+		return isVersionAtLeast(version, []int{10, 6, 0})
+	}
+
+	return true
+}
+
+// generateMySQLLockStr generates the locking clause for the MySQL server version. MariaDB does not support FOR SHARE, so LOCK IN SHARE MODE is used instead
+func generateMySQLLockStr(version string, lock query.Lock) string {
+	if lock.Strength == query.ForShareLock && strings.Contains(strings.ToLower(version), "mariadb") {
+		lock.Strength = "LOCK IN SHARE MODE"
+	}
+
+	return generateLockStr(lock)
+}
+
 // prepareCreateSQLQuery method prepares the create query statement
 func (c MySQLClient) prepareCreateQuery(q QueryInterface) string {
-	ifNotExists := ""
-	if q.GetIfNotExists() {
-		ifNotExists = "IF NOT EXISTS "
+	if selectQuery, ok := q.GetValues().(QueryInterface); ok {
+		return fmt.Sprintf("%s AS %s;", generateCreateTableStr(q), c.prepareSelectQuery(selectQuery))
 	}
 
-	queryStr := fmt.Sprintf("CREATE TABLE %s%s (", ifNotExists, q.GetDestination().GetTableName())
+	queryStr := fmt.Sprintf("%s (", generateCreateTableStr(q))
 
-	if q.GetDestination().GetPrimaryKey() != *(new(dto.ModelField)) {
+	var isComposite = len(q.GetDestination().GetPrimaryKeys()) > 1
+	if q.GetDestination().GetPrimaryKey() != *(new(dto.ModelField)) && !isComposite {
 		queryStr += generateColumnSQLStr(q.GetDestination().GetPrimaryKey())
 
 		if len(q.GetDestination().GetColumns()) > 1 {
@@ -205,11 +911,15 @@ func (c MySQLClient) prepareCreateQuery(q QueryInterface) string {
 	}
 
 	if len(q.GetDestination().GetColumns()) > 0 {
-		queryStr += generateColumnsWithTypesSQLStr(q.GetDestination().GetColumns())
+		queryStr += generateColumnsWithTypesSQLStr(prepareCreateColumns(q.GetDestination()))
+	}
+
+	if q.GetDestination().GetPrimaryKey() != *(new(dto.ModelField)) || isComposite {
+		queryStr += fmt.Sprintf(",\nPRIMARY KEY (%s)", strings.Join(getPrimaryKeysNames(q.GetDestination()), ", "))
 	}
 
-	if q.GetDestination().GetPrimaryKey() != *(new(dto.ModelField)) {
-		queryStr += fmt.Sprintf(",\nPRIMARY KEY (%s)", q.GetDestination().GetPrimaryKey().Name)
+	if len(q.GetConstraintsToAdd()) > 0 {
+		queryStr += fmt.Sprintf(",\n%s", generateConstraintsStr(q.GetConstraintsToAdd()))
 	}
 
 	if len(q.GetForeignKeysToAdd()) > 0 {
@@ -221,24 +931,55 @@ func (c MySQLClient) prepareCreateQuery(q QueryInterface) string {
 	}
 
 	queryStr += ")"
+	queryStr += c.generateTableOptionsSQLStr(q.GetDestination().GetTableOptions())
+	queryStr += ";"
 
-	if c.Config.GetEngine() != "" {
-		queryStr += fmt.Sprintf(" ENGINE=%s", c.Config.GetEngine())
+	return queryStr
+}
+
+// generateTableOptionsSQLStr generates the options of the table. The engine, charset and collation of the model are used instead of the options from the database config
+func (c MySQLClient) generateTableOptionsSQLStr(options dto.TableOptions) string {
+	var queryStr string
+	if options.Engine == "" {
+		options.Engine = c.Config.GetEngine()
 	}
 
-	if c.Config.GetCharset() != "" {
-		queryStr += fmt.Sprintf(" DEFAULT CHARSET=%s", c.Config.GetCharset())
+	if options.Charset == "" {
+		options.Charset = c.Config.GetCharset()
 	}
 
-	if c.Config.GetCollate() != "" {
-		queryStr += fmt.Sprintf(" COLLATE=%s", c.Config.GetCollate())
+	if options.Collate == "" {
+		options.Collate = c.Config.GetCollate()
 	}
 
-	queryStr += ";"
+	if options.Engine != "" {
+		queryStr += fmt.Sprintf(" ENGINE=%s", options.Engine)
+	}
+
+	if options.AutoIncrement > 0 {
+		queryStr += fmt.Sprintf(" AUTO_INCREMENT=%d", options.AutoIncrement)
+	}
+
+	if options.Charset != "" {
+		queryStr += fmt.Sprintf(" DEFAULT CHARSET=%s", options.Charset)
+	}
+
+	if options.Collate != "" {
+		queryStr += fmt.Sprintf(" COLLATE=%s", options.Collate)
+	}
+
+	if options.Comment != "" {
+		queryStr += fmt.Sprintf(" COMMENT=%s", quoteSQLString(options.Comment))
+	}
 
 	return queryStr
 }
 
+// quoteSQLString returns the string literal for the comments of the tables and columns
+func quoteSQLString(value string) string {
+	return fmt.Sprintf("'%s'", strings.NewReplacer(`\`, `\\`, "'", "''").Replace(value))
+}
+
 func generateColumnsWithTypesSQLStr(columns []interface{}) string {
 	var result []string
 	for _, column := range columns {
@@ -269,7 +1010,11 @@ func generateColumnSQLStr(column dto.ModelField) string {
 		resultStr += " unsigned"
 	}
 
-	if column.Default != nil {
+	resultStr += generateColumnCharsetSQLStr(column)
+
+	if column.Generated != "" {
+		resultStr += fmt.Sprintf(" %s", generateGeneratedColumnStr(column))
+	} else if column.Default != nil {
 		resultStr += " DEFAULT"
 		switch v := column.Default.(type) {
 		case int:
@@ -280,9 +1025,15 @@ func generateColumnSQLStr(column dto.ModelField) string {
 			resultStr += fmt.Sprintf(` "%s"`, v)
 		case bool:
 			resultStr += fmt.Sprintf(" %t", v)
+		case dto.RawExpression:
+			resultStr += fmt.Sprintf(" %s", v)
 		}
 	}
 
+	if column.OnUpdate != "" {
+		resultStr += fmt.Sprintf(" ON UPDATE %s", column.OnUpdate)
+	}
+
 	if column.IsNullable {
 		resultStr += fmt.Sprintf(" %s", "NULL")
 	} else {
@@ -293,6 +1044,23 @@ func generateColumnSQLStr(column dto.ModelField) string {
 		resultStr += " AUTO_INCREMENT"
 	}
 
+	if column.Comment != "" {
+		resultStr += fmt.Sprintf(" COMMENT %s", quoteSQLString(column.Comment))
+	}
+
+	return resultStr
+}
+
+// generateColumnCharsetSQLStr generates the CHARACTER SET and COLLATE clauses of the column
+func generateColumnCharsetSQLStr(column dto.ModelField) (resultStr string) {
+	if column.Charset != "" {
+		resultStr += fmt.Sprintf(" CHARACTER SET %s", column.Charset)
+	}
+
+	if column.Collate != "" {
+		resultStr += fmt.Sprintf(" COLLATE %s", column.Collate)
+	}
+
 	return resultStr
 }
 
@@ -325,20 +1093,62 @@ func generateForeignKeySQL(column dto.ForeignKey) string {
 	return str
 }
 
-func generateIndexSQLStr(column dto.Index) string {
-	resultStr := ""
-	if column.Unique {
-		resultStr = "UNIQUE "
+func generateIndexSQLStr(index dto.Index) string {
+	return fmt.Sprintf("%sKEY %s (%s)%s", generateIndexPrefixSQLStr(index), index.Name, generateIndexColumnsStr(index, true), generateIndexTypeSQLStr(index))
+}
+
+// generateIndexPrefixSQLStr generates the UNIQUE, FULLTEXT or SPATIAL keyword of the index
+func generateIndexPrefixSQLStr(index dto.Index) string {
+	switch {
+	case index.Type == dto.FullTextIndexType || index.Type == dto.SpatialIndexType:
+		return fmt.Sprintf("%s ", index.Type)
+	case index.Unique:
+		return "UNIQUE "
 	}
 
-	resultStr += fmt.Sprintf("KEY %s (%s)", column.Name, column.Key)
-	return resultStr
+	return ""
+}
+
+// generateIndexTypeSQLStr generates the USING clause for the BTREE and HASH index types
+func generateIndexTypeSQLStr(index dto.Index) string {
+	if index.Type == dto.BTreeIndexType || index.Type == dto.HashIndexType {
+		return fmt.Sprintf(" USING %s", index.Type)
+	}
+
+	return ""
+}
+
+// prepareTruncateQuery method prepares the truncate table statement
+func (c MySQLClient) prepareTruncateQuery(q QueryInterface) string {
+	return fmt.Sprintf("TRUNCATE TABLE %s", q.GetDestination().GetTableName())
+}
+
+// prepareCreateViewQuery method prepares the create view statement
+func (c MySQLClient) prepareCreateViewQuery(q QueryInterface) string {
+	if q.GetViewQuery() == nil {
+		return ""
+	}
+
+	var queryStr = "CREATE "
+	if q.GetOrReplace() {
+		queryStr += "OR REPLACE "
+	}
+
+	return queryStr + fmt.Sprintf("VIEW %s AS %s", q.GetDestination().GetTableName(), c.prepareSelectQuery(q.GetViewQuery()))
+}
+
+// prepareCreateTriggerQuery method prepares the create trigger statement
+func (c MySQLClient) prepareCreateTriggerQuery(q QueryInterface) string {
+	return fmt.Sprintf("CREATE TRIGGER %s FOR EACH ROW %s", generateTriggerStr(q.GetTrigger()), generateTriggerBodyStr(q.GetTrigger().Body))
 }
 
 // prepareAlterSQLStr method prepares the alter query statement
 func (c MySQLClient) prepareAlterQuery(q QueryInterface) string {
 	var queryStr = fmt.Sprintf("ALTER TABLE %s", q.GetDestination().GetTableName())
 
+	//MySQL does not support IF NOT EXISTS and IF EXISTS clauses of the indexes and foreign keys, so they are generated only for MariaDB. For MySQL they are emulated during the execution, see emulateIndexIfExists
+	var isIfExistsSupported = strings.Contains(strings.ToLower(c.version), "mariadb")
+
 	var result []string
 	//Generate Add columns
 	if len(q.GetColumns()) > 0 {
@@ -360,34 +1170,47 @@ func (c MySQLClient) prepareAlterQuery(q QueryInterface) string {
 		}
 	}
 
-	//Generate indexes to add
-	if len(q.GetIndexesToAdd()) > 0 {
-		for _, column := range q.GetIndexesToAdd() {
-			str := "ADD"
-			if column.Unique {
-				str += " UNIQUE"
-			}
+	//Generate columns to rename. If the renamed column is modified too, the CHANGE clause is used
+	var changed = map[string]bool{}
+	for _, rename := range q.GetColumnsToRename() {
+		column, ok := findModifiedColumn(q, rename.To)
+		if !ok {
+			result = append(result, fmt.Sprintf("RENAME COLUMN %s TO %s", rename.From, rename.To))
+			continue
+		}
 
-			str += " INDEX"
-			if column.Name != "" {
-				str += fmt.Sprintf(" %s", column.Name)
-			}
+		changed[rename.To] = true
+		result = append(result, fmt.Sprintf("CHANGE COLUMN %s %s", rename.From, generateColumnSQLStr(column)))
+	}
 
-			str += fmt.Sprintf(" (%s)", column.Key)
-			result = append(result, str)
+	//Generate columns to modify
+	for _, column := range q.GetColumnsToModify() {
+		if changed[column.Name] {
+			continue
 		}
+
+		result = append(result, fmt.Sprintf("MODIFY COLUMN %s", generateColumnSQLStr(column)))
 	}
 
 	//Generate indexes to add
-	if len(q.GetIndexesToDrop()) > 0 {
-		for _, column := range q.GetIndexesToDrop() {
-			key := column.Name
-			if key == "" {
-				key = column.Key
-			}
+	for _, index := range q.GetIndexesToAdd() {
+		str := fmt.Sprintf("ADD %sINDEX", generateIndexPrefixSQLStr(index))
+		if index.IfNotExists && isIfExistsSupported {
+			str += " IF NOT EXISTS"
+		}
 
-			result = append(result, fmt.Sprintf("DROP INDEX %s", key))
+		if index.Name != "" {
+			str += fmt.Sprintf(" %s", index.Name)
 		}
+
+		str += fmt.Sprintf(" (%s)%s", generateIndexColumnsStr(index, true), generateIndexTypeSQLStr(index))
+		result = append(result, str)
+	}
+
+	//Generate indexes to drop
+	for _, index := range q.GetIndexesToDrop() {
+		index.IfExists = index.IfExists && isIfExistsSupported
+		result = append(result, generateDropIndexStr(index))
 	}
 
 	//Generate foreign keys to add
@@ -400,10 +1223,32 @@ func (c MySQLClient) prepareAlterQuery(q QueryInterface) string {
 	//Generate foreign keys to drop
 	if len(q.GetForeignKeysToDrop()) > 0 {
 		for _, column := range q.GetForeignKeysToDrop() {
+			if column.IfExists && isIfExistsSupported {
+				result = append(result, fmt.Sprintf("DROP FOREIGN KEY IF EXISTS %s", column.Name))
+				continue
+			}
+
 			result = append(result, fmt.Sprintf("DROP FOREIGN KEY %s", column.Name))
 		}
 	}
 
+	//Generate constraints to add
+	for _, constraint := range q.GetConstraintsToAdd() {
+		result = append(result, fmt.Sprintf("ADD %s", generateConstraintStr(constraint)))
+	}
+
+	//Generate constraints to drop
+	for _, constraint := range q.GetConstraintsToDrop() {
+		switch constraint.Type {
+		case dto.PrimaryKeyConstraint:
+			result = append(result, "DROP PRIMARY KEY")
+		case dto.UniqueConstraint:
+			result = append(result, fmt.Sprintf("DROP INDEX %s", constraint.Name))
+		default:
+			result = append(result, fmt.Sprintf("DROP CONSTRAINT %s", constraint.Name))
+		}
+	}
+
 	if len(result) > 0 {
 		queryStr += fmt.Sprintf("\n%s", strings.Join(result, ","))
 	}
@@ -412,14 +1257,27 @@ func (c MySQLClient) prepareAlterQuery(q QueryInterface) string {
 }
 
 func generateAlterColumnAddSQLStr(column dto.ModelField) string {
+	//The default value can't be specified for the generated column
+	if column.Generated != "" {
+		return fmt.Sprintf("ADD %s", generateColumnSQLStr(column))
+	}
+
 	var result = "ADD "
 
 	result += fmt.Sprintf("%s %s", column.Name, column.Type)
 	if column.Length > 0 {
 		result += fmt.Sprintf("(%d)", column.Length)
 	}
+	result += generateColumnCharsetSQLStr(column)
 	result += fmt.Sprintf(" %s", toSQLValue(column.Value))
 	result += fmt.Sprintf(" DEFAULT %s", toSQLValue(column.Default))
+	if column.OnUpdate != "" {
+		result += fmt.Sprintf(" ON UPDATE %s", column.OnUpdate)
+	}
+
+	if column.Comment != "" {
+		result += fmt.Sprintf(" COMMENT %s", quoteSQLString(column.Comment))
+	}
 
 	return result
 }
@@ -1,6 +1,9 @@
 package clients
 
 import (
+	"errors"
+	"fmt"
+	"strings"
 	"testing"
 
 	"github.com/sharovik/orm/dto"
@@ -131,6 +134,23 @@ var (
 					To:   11,
 				})),
 		},
+		{
+			Expected: `SELECT * FROM test_table_name LIMIT 10 OFFSET 20`,
+			Original: MySQLClient{}.ToSql(new(Query).Select([]interface{}{}).
+				From(&m).
+				Limit(query.Limit{
+					Offset: 20,
+					Count:  10,
+				})),
+		},
+		{
+			Expected: `SELECT * FROM test_table_name LIMIT 10`,
+			Original: MySQLClient{}.ToSql(new(Query).Select([]interface{}{}).
+				From(&m).
+				Limit(query.Limit{
+					Count: 10,
+				})),
+		},
 		{
 			Expected: `SELECT * FROM test_table_name WHERE test_table_name2.relation_id = 2 AND col1 = "test" AND ? = ? LIMIT 11`,
 			Original: MySQLClient{}.ToSql(new(Query).Select([]interface{}{}).
@@ -260,6 +280,22 @@ var (
 					Type:     query.WhereAndType,
 				})),
 		},
+		{
+			Expected: "SELECT relation_id, COUNT(id) AS total FROM test_table_name WHERE col1 = ? GROUP BY relation_id HAVING COUNT(id) > ?",
+			Original: MySQLClient{}.ToSql(new(Query).Select([]interface{}{"relation_id", "COUNT(id) AS total"}).
+				From(&m).
+				Where(query.Where{
+					First:    "col1",
+					Operator: "=",
+					Second:   query.Bind{Field: "col1", Value: 2},
+				}).
+				GroupBy("relation_id").
+				Having(query.Where{
+					First:    "COUNT(id)",
+					Operator: ">",
+					Second:   query.Bind{Field: "total", Value: 1},
+				})),
+		},
 	}
 )
 
@@ -299,6 +335,26 @@ func TestMySQLClient_DropToSql(t *testing.T) {
 				Expected: "DROP TABLE test_table_name",
 				Original: MySQLClient{}.ToSql(new(Query).Drop(&model)),
 			},
+			{
+				Expected: "DROP TABLE IF EXISTS test_table_name",
+				Original: MySQLClient{}.ToSql(new(Query).Drop(&model).IfExists()),
+			},
+			{
+				Expected: "TRUNCATE TABLE test_table_name",
+				Original: MySQLClient{}.ToSql(new(Query).Truncate(&model)),
+			},
+			{
+				Expected: "ALTER TABLE test_table_name\nDROP INDEX col1_index,DROP FOREIGN KEY fk_test",
+				Original: MySQLClient{}.ToSql(new(Query).Alter(&model).
+					DropIndex(dto.Index{Name: "col1_index", IfExists: true}).
+					DropForeignKey(dto.ForeignKey{Name: "fk_test", IfExists: true})),
+			},
+			{
+				Expected: "ALTER TABLE test_table_name\nDROP INDEX IF EXISTS col1_index,DROP FOREIGN KEY IF EXISTS fk_test",
+				Original: MySQLClient{version: "10.11.6-MariaDB"}.ToSql(new(Query).Alter(&model).
+					DropIndex(dto.Index{Name: "col1_index", IfExists: true}).
+					DropForeignKey(dto.ForeignKey{Name: "fk_test", IfExists: true})),
+			},
 		}
 	)
 
@@ -307,6 +363,41 @@ func TestMySQLClient_DropToSql(t *testing.T) {
 	}
 }
 
+func TestFilterIndexIfExists(t *testing.T) {
+	var (
+		model   = initTestModel("test_table_name")
+		objects = map[string]bool{"col1_index": true, "fk_test": true}
+		checked []string
+		q       = new(Query).Alter(&model).
+			AddIndex(dto.Index{Name: "col1_index", Key: "col1", IfNotExists: true}).
+			AddIndex(dto.Index{Name: "col2_index", Key: "col2", IfNotExists: true}).
+			DropIndex(dto.Index{Name: "col1_index", IfExists: true}).
+			DropIndex(dto.Index{Name: "col3_index", IfExists: true}).
+			DropForeignKey(dto.ForeignKey{Name: "fk_test", IfExists: true}).
+			DropForeignKey(dto.ForeignKey{Name: "fk_missing", IfExists: true})
+	)
+
+	assert.True(t, hasIndexIfExists(q))
+	assert.False(t, hasIndexIfExists(new(Query).Create(&model).AddIndex(dto.Index{Name: "col1_index", Key: "col1", IfNotExists: true})))
+
+	filtered, err := filterIndexIfExists(q, func(isForeignKey bool, name string) (bool, error) {
+		checked = append(checked, fmt.Sprintf("%t:%s", isForeignKey, name))
+		return objects[name], nil
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"false:col1_index", "false:col2_index", "false:col1_index", "false:col3_index", "true:fk_test", "true:fk_missing"}, checked)
+	assert.Equal(t, "ALTER TABLE test_table_name\nADD INDEX col2_index (col2),DROP INDEX col1_index,DROP FOREIGN KEY fk_test", MySQLClient{}.ToSql(filtered))
+	assert.False(t, hasIndexIfExists(filtered))
+
+	//The original query is not changed
+	assert.True(t, hasIndexIfExists(q))
+
+	_, err = filterIndexIfExists(q, func(bool, string) (bool, error) {
+		return false, errors.New("connection refused")
+	})
+	assert.Error(t, err)
+}
+
 func TestMySQLClient_AlterToSql(t *testing.T) {
 	var (
 		model     = initTestModel("test_table_name")
@@ -567,7 +658,7 @@ func TestMySQLClient_UpdateToSql(t *testing.T) {
 				Original: MySQLClient{}.ToSql(new(Query).Update(&model)),
 			},
 			{
-				Expected: "UPDATE test_table_name SET relation_id = ?, col1 = ?, col2 = ?, col3 = ? LEFT JOIN test ON (test.ref_id = test_table_name.id)",
+				Expected: "UPDATE test_table_name LEFT JOIN test ON (test.ref_id = test_table_name.id) SET relation_id = ?, col1 = ?, col2 = ?, col3 = ?",
 				Original: MySQLClient{}.ToSql(new(Query).Update(&model).Join(query.Join{
 					Target: query.Reference{
 						Table: "test",
@@ -589,6 +680,18 @@ func TestMySQLClient_UpdateToSql(t *testing.T) {
 					Second:   "test",
 				})),
 			},
+			{
+				Expected: "UPDATE test_table_name SET relation_id = ?, col1 = col1 + ?, col2 = ?, col3 = ? WHERE id = ?",
+				Original: MySQLClient{}.ToSql(new(Query).Update(&model).Increment("col1", 1).Where(query.Eq("id", 1))),
+			},
+			{
+				Expected: "UPDATE test_table_name SET col1 = col1 - ?, col3 = CURRENT_TIMESTAMP, col2 = (SELECT MAX(col2) FROM test_table_name2) WHERE id = ?",
+				Original: MySQLClient{}.ToSql(new(Query).UpdateTable("test_table_name").
+					Decrement("col1", 2).
+					Set("col3", query.Expression{SQL: "CURRENT_TIMESTAMP"}).
+					Set("col2", new(Query).Select([]interface{}{"MAX(col2)"}).From("test_table_name2")).
+					Where(query.Eq("id", 1))),
+			},
 		}
 	)
 
@@ -607,7 +710,7 @@ func TestMySQLClient_DeleteToSql(t *testing.T) {
 				Original: MySQLClient{}.ToSql(new(Query).Delete().From(&model)),
 			},
 			{
-				Expected: "DELETE FROM test_table_name LEFT JOIN test_table_name2 ON (test_table_name2.id = test_table_name.relation_id)",
+				Expected: "DELETE test_table_name FROM test_table_name LEFT JOIN test_table_name2 ON (test_table_name2.id = test_table_name.relation_id)",
 				Original: MySQLClient{}.ToSql(new(Query).Delete().
 					From(&model).
 					Join(query.Join{
@@ -671,3 +774,495 @@ func TestMySQLClient_Transactions(t *testing.T) {
 	assert.Equal(t, "COMMIT;", MySQLClient{}.ToSql(new(Query).CommitTransaction()))
 	assert.Equal(t, "ROLLBACK;", MySQLClient{}.ToSql(new(Query).RollbackTransaction()))
 }
+
+func TestIsIntersectExceptSupported(t *testing.T) {
+	assert.False(t, isIntersectExceptSupported("5.7.44"))
+	assert.False(t, isIntersectExceptSupported("8.0.30-log"))
+	assert.True(t, isIntersectExceptSupported("8.0.31"))
+	assert.True(t, isIntersectExceptSupported("8.4.0"))
+	assert.False(t, isIntersectExceptSupported("10.2.44-MariaDB"))
+	assert.True(t, isIntersectExceptSupported("10.6.12-MariaDB-log"))
+}
+
+func TestIsWindowFunctionsSupported(t *testing.T) {
+	assert.False(t, isWindowFunctionsSupported("5.7.44"))
+	assert.True(t, isWindowFunctionsSupported("8.0.0"))
+	assert.False(t, isWindowFunctionsSupported("10.1.48-MariaDB"))
+	assert.True(t, isWindowFunctionsSupported("10.2.44-MariaDB"))
+}
+
+func TestMySQLClient_LockToSql(t *testing.T) {
+	var expectations = []expectation{
+		{
+			Expected: "SELECT id FROM test_table_name WHERE col1 = ? ORDER BY id ASC LIMIT 10 FOR UPDATE SKIP LOCKED",
+			Original: MySQLClient{}.ToSql(new(Query).Select([]interface{}{"id"}).
+				From(&m).
+				Where(query.Eq("col1", 1)).
+				OrderBy("id", query.OrderDirectionAsc).
+				Limit(query.Limit{Count: 10}).
+				ForUpdate().
+				SkipLocked()),
+		},
+		{
+			Expected: "SELECT id FROM test_table_name FOR UPDATE",
+			Original: MySQLClient{}.ToSql(new(Query).Select([]interface{}{"id"}).From(&m).ForUpdate()),
+		},
+		{
+			Expected: "SELECT id FROM test_table_name FOR UPDATE NOWAIT",
+			Original: MySQLClient{}.ToSql(new(Query).Select([]interface{}{"id"}).From(&m).NoWait()),
+		},
+		{
+			Expected: "SELECT id FROM test_table_name FOR SHARE",
+			Original: MySQLClient{}.ToSql(new(Query).Select([]interface{}{"id"}).From(&m).ForShare()),
+		},
+		{
+			Expected: "SELECT id FROM test_table_name WHERE id IN (SELECT relation_id FROM test_table_name2) FOR SHARE NOWAIT",
+			Original: MySQLClient{}.ToSql(new(Query).Select([]interface{}{"id"}).
+				From(&m).
+				Where(query.Where{
+					First:    "id",
+					Operator: query.InOperator,
+					Second:   new(Query).Select([]interface{}{"relation_id"}).From("test_table_name2"),
+				}).
+				ForShare().
+				NoWait()),
+		},
+	}
+
+	for _, e := range expectations {
+		assert.Equal(t, e.Expected, e.Original)
+	}
+
+	//MariaDB uses LOCK IN SHARE MODE instead of FOR SHARE
+	assert.Equal(t, "SELECT id FROM test_table_name LOCK IN SHARE MODE NOWAIT", MySQLClient{version: "10.11.6-MariaDB"}.ToSql(new(Query).Select([]interface{}{"id"}).From(&m).ForShare().NoWait()))
+	assert.Equal(t, "SELECT id FROM test_table_name FOR SHARE", MySQLClient{version: "8.0.36"}.ToSql(new(Query).Select([]interface{}{"id"}).From(&m).ForShare()))
+	assert.Equal(t, "SELECT id FROM test_table_name FOR UPDATE", MySQLClient{version: "10.11.6-MariaDB"}.ToSql(new(Query).Select([]interface{}{"id"}).From(&m).ForUpdate()))
+
+	err := MySQLClient{}.validateQuery(new(Query).Select([]interface{}{"id"}).
+		From(&m).
+		Union(new(Query).Select([]interface{}{"id"}).From("test_table_name2")).
+		ForUpdate())
+	assert.Error(t, err)
+}
+
+func TestIsLockSupported(t *testing.T) {
+	assert.True(t, isLockSupported("5.7.44", query.Lock{Strength: query.ForUpdateLock}))
+	assert.False(t, isLockSupported("5.7.44", query.Lock{Strength: query.ForShareLock}))
+	assert.False(t, isLockSupported("5.7.44", query.Lock{Strength: query.ForUpdateLock, Option: query.SkipLockedLockOption}))
+	assert.True(t, isLockSupported("8.0.36", query.Lock{Strength: query.ForShareLock, Option: query.NoWaitLockOption}))
+	assert.True(t, isLockSupported("10.11.6-MariaDB", query.Lock{Strength: query.ForShareLock}))
+	assert.False(t, isLockSupported("10.2.44-MariaDB", query.Lock{Strength: query.ForShareLock, Option: query.NoWaitLockOption}))
+	assert.True(t, isLockSupported("10.3.39-MariaDB", query.Lock{Strength: query.ForUpdateLock, Option: query.NoWaitLockOption}))
+	assert.False(t, isLockSupported("10.5.23-MariaDB", query.Lock{Strength: query.ForUpdateLock, Option: query.SkipLockedLockOption}))
+	assert.True(t, isLockSupported("10.6.16-MariaDB", query.Lock{Strength: query.ForUpdateLock, Option: query.SkipLockedLockOption}))
+}
+
+func TestMySQLClient_JoinedUpdateBindings(t *testing.T) {
+	model := initTestModel("test_table_name")
+	q := new(Query).UpdateTable(&model).
+		Set("col1", 10).
+		Increment("col2", 1).
+		Join(query.Join{
+			Target:    query.Reference{Table: "test_table_name2", Key: "relation_id"},
+			With:      query.Reference{Table: "test_table_name", Key: "id"},
+			Condition: "=",
+			Type:      query.InnerJoinType,
+			On:        []query.Where{query.Eq("test_table_name2.col3", "test")},
+		}).
+		Where(query.Eq("test_table_name.col3", "value"))
+
+	assert.Equal(t, "UPDATE test_table_name INNER JOIN test_table_name2 ON (test_table_name2.relation_id = test_table_name.id AND test_table_name2.col3 = ?) SET col1 = ?, col2 = col2 + ? WHERE test_table_name.col3 = ?", MySQLClient{}.ToSql(q))
+	assert.Equal(t, []query.Bind{
+		{Field: "test_table_name2.col3", Value: "test"},
+		{Field: "col1", Value: 10},
+		{Field: "col2", Value: 1},
+		{Field: "test_table_name.col3", Value: "value"},
+	}, MySQLClient{}.prepareBindings(q))
+
+	assert.Error(t, MySQLClient{}.validateQuery(new(Query).Delete().From(&model).Join(query.Join{
+		Target:    query.Reference{Table: "test_table_name2", Key: "relation_id"},
+		With:      query.Reference{Table: "test_table_name", Key: "id"},
+		Condition: "=",
+	}).Limit(query.Limit{Count: 10})))
+}
+
+func TestMySQLClient_NestedFullJoin(t *testing.T) {
+	var (
+		model    = initTestModel("test_table_name")
+		fullJoin = func() QueryInterface {
+			return new(Query).Select([]interface{}{"test_table_name.id"}).
+				From(&model).
+				Join(query.Join{
+					Target:    query.Reference{Table: "test_table_name2", Key: "relation_id"},
+					With:      query.Reference{Table: "test_table_name", Key: "id"},
+					Condition: "=",
+					Type:      query.FullJoinType,
+				}).
+				Where(query.Eq("test_table_name.col2", 2))
+		}
+	)
+
+	//The FULL OUTER JOIN of the nested queries is not emulated
+	for _, q := range []QueryInterface{
+		new(Query).Select([]interface{}{"id"}).From(&model).Where(query.Where{First: "id", Operator: "IN", Second: fullJoin()}),
+		new(Query).Select([]interface{}{"id"}).From(SubQuery{Query: fullJoin(), Alias: "t"}),
+		new(Query).With("t", fullJoin()).Select([]interface{}{"id"}).From("t"),
+		new(Query).Select([]interface{}{"id"}).From(&model).Union(fullJoin()),
+		new(Query).Select([]interface{}{"id"}).From(&model).Join(query.Join{
+			Target: query.Reference{Table: "t", Key: "id"},
+			With:   query.Reference{Table: "test_table_name", Key: "id"},
+			Query:  fullJoin(),
+		}),
+	} {
+		assert.ErrorContains(t, MySQLClient{}.validateQuery(q), "FULL OUTER JOIN cannot be used in the subqueries")
+	}
+
+	//The select query of CREATE TABLE ... AS SELECT statement is emulated by the client
+	q := new(Query).CreateFromSelect("staging", fullJoin())
+	assert.NoError(t, MySQLClient{}.validateQuery(q))
+	assert.Equal(t, "CREATE TABLE staging AS SELECT test_table_name.id FROM test_table_name LEFT JOIN test_table_name2 ON (test_table_name2.relation_id = test_table_name.id) WHERE test_table_name.col2 = ? UNION ALL SELECT test_table_name.id FROM test_table_name RIGHT JOIN test_table_name2 ON (test_table_name2.relation_id = test_table_name.id) WHERE test_table_name.col2 = ? AND test_table_name.id IS NULL;", MySQLClient{}.ToSql(q))
+	assert.Equal(t, []query.Bind{
+		{Field: "test_table_name.col2", Value: 2},
+		{Field: "test_table_name.col2", Value: 2},
+	}, MySQLClient{}.prepareBindings(q))
+}
+
+// testWrappedQuery the custom implementation of QueryInterface
+type testWrappedQuery struct {
+	*Query
+}
+
+func (q testWrappedQuery) Clone() QueryInterface {
+	return testWrappedQuery{Query: q.Query.Clone().(*Query)}
+}
+
+func TestMySQLClient_FullJoinDuplicateRows(t *testing.T) {
+	removeDatabase()
+	initDatabase()
+	defer removeDatabase()
+
+	sqliteClient, err := SQLiteClient{}.Connect(DatabaseConfig{
+		Host: testSQLiteDatabasePath,
+	})
+	assert.NoError(t, err)
+
+	for _, statement := range []string{
+		"CREATE TABLE parents (id INTEGER PRIMARY KEY, name VARCHAR)",
+		"CREATE TABLE children (id INTEGER PRIMARY KEY, parent_id INTEGER)",
+		"INSERT INTO parents (id, name) VALUES (1, 'x'), (2, 'x'), (3, 'y')",
+		"INSERT INTO children (id, parent_id) VALUES (1, 1), (2, 1), (3, 9), (4, 9)",
+	} {
+		_, err = sqliteClient.GetClient().Exec(statement)
+		assert.NoError(t, err)
+	}
+
+	q := new(Query).Select([]interface{}{"parents.name", "children.parent_id"}).
+		From("parents").
+		Join(query.Join{
+			Target:    query.Reference{Table: "children", Key: "parent_id"},
+			With:      query.Reference{Table: "parents", Key: "id"},
+			Condition: "=",
+			Type:      query.FullJoinType,
+		})
+	assert.NoError(t, MySQLClient{}.validateQuery(q))
+
+	//The duplicate rows of both sides are kept by the emulated query, the same as by the native FULL OUTER JOIN
+	countRows := func(queryStr string, bindings []query.Bind) (count int) {
+		var values []interface{}
+		for _, bind := range bindings {
+			values = append(values, bind.Value)
+		}
+
+		rows, err := sqliteClient.GetClient().Query(queryStr, values...)
+		assert.NoError(t, err)
+		defer rows.Close()
+
+		for rows.Next() {
+			count++
+		}
+
+		return count
+	}
+
+	expected := countRows(SQLiteClient{}.ToSql(q), SQLiteClient{}.prepareBindings(q))
+	assert.Equal(t, 6, expected)
+	assert.Equal(t, expected, countRows(strings.TrimSuffix(MySQLClient{}.ToSql(q), ";"), MySQLClient{}.prepareBindings(q)))
+
+	//The FULL OUTER JOIN without the keys cannot be emulated, because the unmatched rows of the joined table cannot be selected
+	assert.ErrorContains(t, MySQLClient{}.validateQuery(new(Query).Select([]interface{}{"parents.name"}).From("parents").Join(query.Join{
+		Target: query.Reference{Table: "children"},
+		Type:   query.FullJoinType,
+		On:     []query.Where{{First: "children.parent_id", Operator: "=", Second: "parents.id"}},
+	})), "FULL OUTER JOIN should have the Target.Key and With.Key")
+
+	//The FULL OUTER JOIN of the custom query implementation cannot be emulated
+	assert.ErrorContains(t, MySQLClient{}.validateQuery(testWrappedQuery{Query: q.(*Query)}), "FULL OUTER JOIN can be emulated only")
+}
+
+func TestMySQLClient_ConstraintsToSql(t *testing.T) {
+	model := initCompositeTestModel()
+	var (
+		unique = dto.Constraint{Name: "user_groups_email_uq", Type: dto.UniqueConstraint, Columns: []string{"group_id", "email"}}
+		check  = dto.Constraint{Name: "user_groups_position_ck", Type: dto.CheckConstraint, Expression: "position >= 0"}
+	)
+
+	assert.Equal(t, "CREATE TABLE user_groups (user_id INTEGER NOT NULL, group_id INTEGER NOT NULL, email VARCHAR NOT NULL, position INTEGER NOT NULL,\nPRIMARY KEY (user_id, group_id),\nCONSTRAINT user_groups_email_uq UNIQUE (group_id, email),\nCONSTRAINT user_groups_position_ck CHECK (position >= 0));",
+		MySQLClient{}.ToSql(new(Query).Create(&model).AddConstraint(unique).AddConstraint(check)))
+
+	assert.Equal(t, "ALTER TABLE user_groups\nADD CONSTRAINT user_groups_email_uq UNIQUE (group_id, email),ADD CONSTRAINT user_groups_position_ck CHECK (position >= 0)",
+		MySQLClient{}.ToSql(new(Query).Alter(&model).AddConstraint(unique).AddConstraint(check)))
+
+	assert.Equal(t, "ALTER TABLE user_groups\nDROP INDEX user_groups_email_uq,DROP CONSTRAINT user_groups_position_ck,DROP PRIMARY KEY",
+		MySQLClient{}.ToSql(new(Query).Alter(&model).DropConstraint(unique).DropConstraint(check).DropConstraint(dto.Constraint{Type: dto.PrimaryKeyConstraint})))
+}
+
+func TestMySQLClient_IndexesToSql(t *testing.T) {
+	model := initTestModel("test_table_name")
+	var (
+		composite = dto.Index{
+			Name: "col3_col1_index",
+			Columns: []dto.IndexColumn{
+				{Name: "col3", Length: 10},
+				{Name: "col1", Direction: "desc"},
+			},
+			Type: dto.BTreeIndexType,
+		}
+		expression = dto.Index{
+			Name:    "lower_col3_index",
+			Columns: []dto.IndexColumn{{Expression: "LOWER(col3)"}},
+			Unique:  true,
+		}
+		fullText = dto.Index{Name: "col3_full_text_index", Key: "col3", Type: dto.FullTextIndexType}
+	)
+
+	assert.Equal(t, "CREATE TABLE test_table_name (id INTEGER NOT NULL AUTO_INCREMENT, relation_id INTEGER NOT NULL, col1 INTEGER NOT NULL, col2 INTEGER NOT NULL, col3 VARCHAR NOT NULL,\nPRIMARY KEY (id),\nKEY col3_col1_index (col3(10), col1 DESC) USING BTREE,\nUNIQUE KEY lower_col3_index ((LOWER(col3))),\nFULLTEXT KEY col3_full_text_index (col3));",
+		MySQLClient{}.ToSql(new(Query).Create(&model).AddIndex(composite).AddIndex(expression).AddIndex(fullText)))
+
+	assert.Equal(t, "ALTER TABLE test_table_name\nADD INDEX IF NOT EXISTS hash_index (relation_id, col2) USING HASH,DROP INDEX IF EXISTS old_index",
+		MySQLClient{version: "10.11.6-MariaDB"}.ToSql(new(Query).Alter(&model).
+			AddIndex(dto.Index{
+				Name:        "hash_index",
+				Columns:     []dto.IndexColumn{{Name: "relation_id"}, {Name: "col2"}},
+				Type:        dto.HashIndexType,
+				IfNotExists: true,
+			}).
+			DropIndex(dto.Index{Name: "old_index", IfExists: true})))
+
+	//MySQL does not support these clauses, they are emulated during the execution
+	assert.Equal(t, "ALTER TABLE test_table_name\nADD INDEX hash_index (col3),DROP INDEX old_index",
+		MySQLClient{version: "8.0.36"}.ToSql(new(Query).Alter(&model).
+			AddIndex(dto.Index{Name: "hash_index", Key: "col3", IfNotExists: true}).
+			DropIndex(dto.Index{Name: "old_index", IfExists: true})))
+
+	//The flag is ignored for the index of the created table, so the server version is not required
+	assert.NoError(t, MySQLClient{}.validateQuery(new(Query).Create(&model).AddIndex(dto.Index{
+		Name:        "hash_index",
+		Key:         "col3",
+		IfNotExists: true,
+	})))
+	assert.Equal(t, "CREATE TABLE test_table_name (id INTEGER NOT NULL AUTO_INCREMENT, relation_id INTEGER NOT NULL, col1 INTEGER NOT NULL, col2 INTEGER NOT NULL, col3 VARCHAR NOT NULL,\nPRIMARY KEY (id),\nKEY hash_index (col3));",
+		MySQLClient{}.ToSql(new(Query).Create(&model).AddIndex(dto.Index{Name: "hash_index", Key: "col3", IfNotExists: true})))
+
+	assert.Error(t, MySQLClient{}.validateQuery(new(Query).Alter(&model).AddIndex(dto.Index{
+		Name:  "partial_index",
+		Key:   "col3",
+		Where: "col1 > 0",
+	})))
+}
+
+func TestMySQLClient_AlterColumnsToSql(t *testing.T) {
+	model := initTestModel("test_table_name")
+
+	assert.Equal(t, "ALTER TABLE test_table_name\nRENAME COLUMN col1 TO col4,CHANGE COLUMN col2 col5 VARCHAR(255) NULL,MODIFY COLUMN col3 VARCHAR(100) DEFAULT \"test\" NOT NULL",
+		MySQLClient{}.ToSql(new(Query).Alter(&model).
+			RenameColumn("col1", "col4").
+			RenameColumn("col2", "col5").
+			ModifyColumn(dto.ModelField{Name: "col5", Type: dto.VarcharColumnType, Length: 255, IsNullable: true}).
+			ModifyColumn(dto.ModelField{Name: "col3", Type: dto.VarcharColumnType, Length: 100, Default: "test"})))
+}
+
+func TestIsRenameColumnSupported(t *testing.T) {
+	assert.False(t, isRenameColumnSupported("5.7.44"))
+	assert.True(t, isRenameColumnSupported("8.0.36"))
+	assert.False(t, isRenameColumnSupported("10.4.32-MariaDB"))
+	assert.True(t, isRenameColumnSupported("10.5.2-MariaDB"))
+}
+
+func TestMySQLClient_ViewsToSql(t *testing.T) {
+	var reportQuery = new(Query).Select([]interface{}{"relation_id", "COUNT(id) AS total"}).
+		From("test_table_name").
+		Where(query.Where{First: "col1", Operator: ">", Second: "col2"}).
+		GroupBy("relation_id")
+
+	assert.Equal(t, "CREATE VIEW report AS SELECT relation_id, COUNT(id) AS total FROM test_table_name WHERE col1 > col2 GROUP BY relation_id",
+		MySQLClient{}.ToSql(new(Query).CreateView("report", reportQuery)))
+	assert.Equal(t, "CREATE OR REPLACE VIEW report AS SELECT relation_id, COUNT(id) AS total FROM test_table_name WHERE col1 > col2 GROUP BY relation_id",
+		MySQLClient{}.ToSql(new(Query).CreateView("report", reportQuery).OrReplace()))
+	assert.Equal(t, "DROP VIEW report", MySQLClient{}.ToSql(new(Query).DropView("report")))
+	assert.Equal(t, "DROP VIEW IF EXISTS report", MySQLClient{}.ToSql(new(Query).DropView("report").IfExists()))
+
+	assert.Error(t, MySQLClient{}.validateQuery(new(Query).CreateView("report", new(Query).Select([]interface{}{"id"}).
+		From("test_table_name").
+		Where(query.Eq("col1", 1)))))
+}
+
+func TestMySQLClient_TriggersToSql(t *testing.T) {
+	trigger := dto.Trigger{
+		Name:   "test_table_name_updated",
+		Table:  "test_table_name",
+		Timing: dto.BeforeTriggerTiming,
+		Event:  dto.UpdateTriggerEvent,
+		Body:   "SET NEW.col2 = NEW.col2 + 1;",
+	}
+
+	assert.Equal(t, "CREATE TRIGGER test_table_name_updated BEFORE UPDATE ON test_table_name FOR EACH ROW BEGIN SET NEW.col2 = NEW.col2 + 1; END",
+		MySQLClient{}.ToSql(new(Query).CreateTrigger(trigger)))
+	assert.Equal(t, "DROP TRIGGER IF EXISTS test_table_name_updated", MySQLClient{}.ToSql(new(Query).DropTrigger("test_table_name_updated").IfExists()))
+
+	trigger.Timing = dto.InsteadOfTriggerTiming
+	assert.Error(t, MySQLClient{}.validateQuery(new(Query).CreateTrigger(trigger)))
+
+	assert.Equal(t, "SET NEW.col2 = NEW.col2 + 1", parseTriggerBody("BEGIN\n  SET NEW.col2 = NEW.col2 + 1;\nEND"))
+	assert.Equal(t, "SET NEW.col2 = 1", parseTriggerBody("SET NEW.col2 = 1"))
+}
+
+func TestMySQLClient_TemporaryTablesToSql(t *testing.T) {
+	var (
+		model       = initTestModel("test_table_name")
+		selectQuery = new(Query).Select([]interface{}{"id", "col3"}).From(&model).Where(query.Where{
+			First:    "col3",
+			Operator: "=",
+			Second:   query.Bind{Field: "col3", Value: "test"},
+		})
+		testCases = [...]expectation{
+			{
+				Expected: "CREATE TEMPORARY TABLE IF NOT EXISTS staging AS SELECT id, col3 FROM test_table_name WHERE col3 = ?;",
+				Original: MySQLClient{}.ToSql(new(Query).CreateFromSelect("staging", selectQuery).Temporary().IfNotExists()),
+			},
+			{
+				Expected: "CREATE TABLE staging AS SELECT id, col3 FROM test_table_name WHERE col3 = ?;",
+				Original: MySQLClient{}.ToSql(new(Query).CreateFromSelect("staging", selectQuery)),
+			},
+		}
+	)
+
+	for _, testCase := range testCases {
+		assert.Equal(t, testCase.Expected, testCase.Original)
+	}
+
+	assert.True(t, strings.HasPrefix(MySQLClient{}.ToSql(new(Query).Create(&model).Temporary()), "CREATE TEMPORARY TABLE test_table_name ("))
+}
+
+func TestMySQLClient_GeneratedColumnsToSql(t *testing.T) {
+	var (
+		model     = initTestModel("test_table_name")
+		testCases = [...]expectation{
+			{
+				Expected: "ALTER TABLE test_table_name\nADD updated_at TIMESTAMP NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP",
+				Original: MySQLClient{}.ToSql(new(Query).Alter(&model).AddColumn(dto.ModelField{
+					Name:     "updated_at",
+					Type:     "TIMESTAMP",
+					Default:  dto.RawExpression("CURRENT_TIMESTAMP"),
+					OnUpdate: "CURRENT_TIMESTAMP",
+				})),
+			},
+			{
+				Expected: "ALTER TABLE test_table_name\nADD total INTEGER GENERATED ALWAYS AS (col1 * col2) STORED NOT NULL",
+				Original: MySQLClient{}.ToSql(new(Query).Alter(&model).AddColumn(dto.ModelField{
+					Name:          "total",
+					Type:          dto.IntegerColumnType,
+					Generated:     "col1 * col2",
+					GeneratedType: dto.StoredGeneratedType,
+				})),
+			},
+			{
+				Expected: "ALTER TABLE test_table_name\nMODIFY COLUMN updated_at DATETIME(6) DEFAULT CURRENT_TIMESTAMP(6) ON UPDATE CURRENT_TIMESTAMP(6) NOT NULL",
+				Original: MySQLClient{}.ToSql(new(Query).Alter(&model).ModifyColumn(dto.ModelField{
+					Name:     "updated_at",
+					Type:     "DATETIME",
+					Length:   6,
+					Default:  dto.RawExpression("CURRENT_TIMESTAMP(6)"),
+					OnUpdate: "CURRENT_TIMESTAMP(6)",
+				})),
+			},
+		}
+	)
+
+	for _, testCase := range testCases {
+		assert.Equal(t, testCase.Expected, testCase.Original)
+	}
+
+	model.AddModelField(dto.ModelField{
+		Name:          "title",
+		Type:          dto.VarcharColumnType,
+		Length:        255,
+		Generated:     "upper(col3)",
+		GeneratedType: dto.VirtualGeneratedType,
+		IsNullable:    true,
+	})
+	assert.Contains(t, MySQLClient{}.ToSql(new(Query).Create(&model)), "title VARCHAR(255) GENERATED ALWAYS AS (upper(col3)) VIRTUAL NULL")
+	assert.Equal(t, "INSERT INTO test_table_name (relation_id, col1, col2, col3) VALUES (?, ?, ?, ?)", MySQLClient{}.ToSql(new(Query).Insert(&model)))
+}
+
+func TestMySQLClient_TableOptionsToSql(t *testing.T) {
+	model := dto.BaseModel{
+		TableName: "users",
+		Options: dto.TableOptions{
+			Engine:        "InnoDB",
+			Charset:       "utf8mb4",
+			Collate:       "utf8mb4_unicode_ci",
+			Comment:       "The user's accounts",
+			AutoIncrement: 1000,
+		},
+	}
+	model.SetPrimaryKey(dto.ModelField{Name: "id", Type: dto.IntegerColumnType, IsUnsigned: true, AutoIncrement: true})
+	model.AddModelField(dto.ModelField{Name: "login", Type: dto.VarcharColumnType, Length: 100, Charset: "ascii", Collate: "ascii_bin", Comment: "The unique login"})
+
+	var testCases = [...]expectation{
+		{
+			Expected: "CREATE TABLE users (id INTEGER unsigned NOT NULL AUTO_INCREMENT, login VARCHAR(100) CHARACTER SET ascii COLLATE ascii_bin NOT NULL COMMENT 'The unique login',\nPRIMARY KEY (id)) ENGINE=InnoDB AUTO_INCREMENT=1000 DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='The user''s accounts';",
+			Original: MySQLClient{}.ToSql(new(Query).Create(&model)),
+		},
+		{
+			Expected: "CREATE TABLE users (id INTEGER unsigned NOT NULL AUTO_INCREMENT, login VARCHAR(100) CHARACTER SET ascii COLLATE ascii_bin NOT NULL COMMENT 'The unique login',\nPRIMARY KEY (id)) ENGINE=MyISAM DEFAULT CHARSET=latin1;",
+			Original: MySQLClient{Config: DatabaseConfig{Engine: "MyISAM", Charset: "latin1"}}.ToSql(new(Query).Create(&dto.BaseModel{
+				TableName:  model.TableName,
+				PrimaryKey: model.PrimaryKey,
+				Fields:     model.Fields,
+			})),
+		},
+		{
+			Expected: "ALTER TABLE users\nADD email VARCHAR(255) CHARACTER SET utf8mb4 NULL DEFAULT NULL COMMENT 'The email'",
+			Original: MySQLClient{}.ToSql(new(Query).Alter(&model).AddColumn(dto.ModelField{Name: "email", Type: dto.VarcharColumnType, Length: 255, Charset: "utf8mb4", Comment: "The email"})),
+		},
+	}
+
+	for _, testCase := range testCases {
+		assert.Equal(t, testCase.Expected, testCase.Original)
+	}
+
+	//The options of the model are used instead of the options of the config
+	assert.Contains(t, MySQLClient{Config: DatabaseConfig{Engine: "MyISAM"}}.ToSql(new(Query).Create(&model)), ") ENGINE=InnoDB AUTO_INCREMENT=1000")
+	assert.Equal(t, `'C:\\temp'`, quoteSQLString(`C:\temp`))
+}
+
+func TestMySQLClient_ParseColumns(t *testing.T) {
+	assert.Equal(t, dto.RawExpression("CURRENT_TIMESTAMP"), parseMySQLColumnDefault("CURRENT_TIMESTAMP", "DEFAULT_GENERATED"))
+	assert.Equal(t, dto.RawExpression("current_timestamp()"), parseMySQLColumnDefault("current_timestamp()", ""))
+	assert.Equal(t, dto.RawExpression("'test'"), parseMySQLColumnDefault("'test'", ""))
+	assert.Equal(t, "test", parseMySQLColumnDefault("test", ""))
+
+	var testCases = map[string]dto.ModelField{
+		"auto_increment": {Name: "id", AutoIncrement: true},
+		"DEFAULT_GENERATED on update CURRENT_TIMESTAMP": {Name: "id", OnUpdate: "CURRENT_TIMESTAMP"},
+		"on update current_timestamp()":                 {Name: "id", OnUpdate: "current_timestamp()"},
+		"":                                              {Name: "id"},
+	}
+	for extra, expected := range testCases {
+		assert.Equal(t, expected, parseMySQLColumnExtra(dto.ModelField{Name: "id"}, extra))
+	}
+
+	assert.Equal(t, dto.VirtualGeneratedType, parseMySQLColumnExtra(dto.ModelField{Generated: "`a` * 2"}, "VIRTUAL GENERATED").GeneratedType)
+	assert.Equal(t, dto.StoredGeneratedType, parseMySQLColumnExtra(dto.ModelField{Generated: "`a` * 2"}, "STORED GENERATED").GeneratedType)
+	assert.Equal(t, dto.StoredGeneratedType, parseMySQLColumnExtra(dto.ModelField{Generated: "`a` * 2"}, "PERSISTENT GENERATED").GeneratedType)
+}
@@ -0,0 +1,449 @@
+package clients
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/sharovik/orm/dto"
+	"github.com/sharovik/orm/query"
+)
+
+const (
+	cursorDirectionNext     = "next"
+	cursorDirectionPrevious = "previous"
+
+	cursorValueInteger = "int"
+	cursorValueFloat   = "float"
+	cursorValueString  = "string"
+	cursorValueBytes   = "bytes"
+	cursorValueBool    = "bool"
+	cursorValueTime    = "time"
+
+	countColumnAlias = "total"
+	countQueryAlias  = "count_query"
+)
+
+var (
+	// CursorSigningKey the key which is used for the signature of the pagination cursors.
+	// By default, it is generated randomly on start, so please set your own key if the cursors should be valid after restart or between several instances of your application
+	CursorSigningKey = generateCursorSigningKey()
+
+	// ErrInvalidCursor the error which is returned when the pagination cursor cannot be decoded or it was modified
+	ErrInvalidCursor = errors.New("The pagination cursor is invalid ")
+)
+
+// KeysetPage the page of the keyset pagination. The Query should be executed and its result should be passed to the Result method
+type KeysetPage struct {
+	Query     QueryInterface
+	PageSize  int64
+	orderBy   []query.OrderByColumn
+	backward  bool
+	hasCursor bool
+}
+
+type cursorPayload struct {
+	Direction string        `json:"d"`
+	Columns   []string      `json:"c"`
+	Values    []interface{} `json:"v"`
+	Types     []string      `json:"t"`
+}
+
+// Paginate prepares the query for the keyset (cursor) pagination. The ORDER BY columns of the query are used as the keys, so they must be unique in combination and must be selected by the query.
+// For the first page the cursor should be empty. The cursors for the next and previous pages are returned by KeysetPage.Result method.
+func Paginate(q QueryInterface, cursor string, pageSize int64) (page KeysetPage, err error) {
+	if len(q.GetOrderBy()) == 0 {
+		return page, errors.New("The query for the keyset pagination should have the ORDER BY columns ")
+	}
+
+	if pageSize <= 0 {
+		return page, errors.New("The page size should be greater than zero ")
+	}
+
+	page = KeysetPage{
+		Query:    q.Clone(),
+		PageSize: pageSize,
+		orderBy:  q.GetOrderBy(),
+	}
+
+	if cursor != "" {
+		payload, err := decodeCursor(cursor, page.orderBy)
+		if err != nil {
+			return KeysetPage{}, err
+		}
+
+		if err = groupWheres(page.Query); err != nil {
+			return KeysetPage{}, err
+		}
+
+		page.hasCursor = true
+		page.backward = payload.Direction == cursorDirectionPrevious
+		page.Query.Where(generateSeekWhere(page.orderBy, payload.Values, page.backward))
+	}
+
+	//For the previous page we select the rows in the reverse order and reverse them back in the Result method
+	if page.backward {
+		page.Query.ResetOrderBy()
+		for _, column := range page.orderBy {
+			page.Query.OrderBy(column.Column, reverseOrderDirection(column.Direction))
+		}
+	}
+
+	//We select one more row to understand if there is one more page
+	page.Query.Limit(query.Limit{Count: pageSize + 1})
+
+	return page, nil
+}
+
+// Result returns the items of the page and the cursors for the next and previous pages. The empty cursor means there is no such page
+func (p KeysetPage) Result(result dto.BaseResult) (items []dto.ModelInterface, next string, previous string, err error) {
+	items = result.Items()
+
+	hasMore := int64(len(items)) > p.PageSize
+	if hasMore {
+		items = items[:p.PageSize]
+	}
+
+	if p.backward {
+		for i, j := 0, len(items)-1; i < j; i, j = i+1, j-1 {
+			items[i], items[j] = items[j], items[i]
+		}
+	}
+
+	if len(items) == 0 {
+		return items, "", "", nil
+	}
+
+	hasNext, hasPrevious := hasMore, p.hasCursor
+	if p.backward {
+		hasNext, hasPrevious = true, hasMore
+	}
+
+	if hasNext {
+		if next, err = p.encodeCursor(items[len(items)-1], cursorDirectionNext); err != nil {
+			return nil, "", "", err
+		}
+	}
+
+	if hasPrevious {
+		if previous, err = p.encodeCursor(items[0], cursorDirectionPrevious); err != nil {
+			return nil, "", "", err
+		}
+	}
+
+	return items, next, previous, nil
+}
+
+func (p KeysetPage) encodeCursor(item dto.ModelInterface, direction string) (string, error) {
+	payload := cursorPayload{
+		Direction: direction,
+	}
+
+	for _, column := range p.orderBy {
+		field := item.GetField(column.Column)
+		if field.Name == "" {
+			//The selected column name does not contain the table name
+			field = item.GetField(column.Column[strings.LastIndex(column.Column, ".")+1:])
+		}
+
+		if field.Name == "" {
+			return "", fmt.Errorf("The ORDER BY column %s should be selected for the keyset pagination ", column.Column)
+		}
+
+		valueType, err := getCursorValueType(field.Value)
+		if err != nil {
+			return "", fmt.Errorf("The value of the ORDER BY column %s cannot be used for the keyset pagination: %w", column.Column, err)
+		}
+
+		payload.Columns = append(payload.Columns, column.Column)
+		payload.Values = append(payload.Values, field.Value)
+		payload.Types = append(payload.Types, valueType)
+	}
+
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return "", err
+	}
+
+	return fmt.Sprintf("%s.%s", base64.RawURLEncoding.EncodeToString(data), base64.RawURLEncoding.EncodeToString(signCursor(data))), nil
+}
+
+func decodeCursor(cursor string, orderBy []query.OrderByColumn) (payload cursorPayload, err error) {
+	parts := strings.Split(cursor, ".")
+	if len(parts) != 2 {
+		return payload, ErrInvalidCursor
+	}
+
+	data, err := base64.RawURLEncoding.DecodeString(parts[0])
+	if err != nil {
+		return payload, ErrInvalidCursor
+	}
+
+	signature, err := base64.RawURLEncoding.DecodeString(parts[1])
+	if err != nil || !hmac.Equal(signature, signCursor(data)) {
+		return payload, ErrInvalidCursor
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	if err = decoder.Decode(&payload); err != nil {
+		return payload, ErrInvalidCursor
+	}
+
+	//The cursor can be used only for the query with the same ORDER BY columns
+	if len(payload.Columns) != len(orderBy) || len(payload.Values) != len(orderBy) || len(payload.Types) != len(orderBy) {
+		return payload, ErrInvalidCursor
+	}
+
+	for i, column := range orderBy {
+		if payload.Columns[i] != column.Column {
+			return payload, ErrInvalidCursor
+		}
+
+		if payload.Values[i], err = parseCursorValue(payload.Values[i], payload.Types[i]); err != nil {
+			return payload, ErrInvalidCursor
+		}
+	}
+
+	return payload, nil
+}
+
+// getCursorValueType returns the type of the key value, which is stored in the cursor, so the value is restored with the same type.
+// The NULL values cannot be compared, so they are not supported
+func getCursorValueType(value interface{}) (string, error) {
+	switch value.(type) {
+	case int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64:
+		return cursorValueInteger, nil
+	case float32, float64:
+		return cursorValueFloat, nil
+	case string:
+		return cursorValueString, nil
+	case []byte:
+		return cursorValueBytes, nil
+	case bool:
+		return cursorValueBool, nil
+	case time.Time:
+		return cursorValueTime, nil
+	case nil:
+		return "", errors.New("The NULL value cannot be compared ")
+	}
+
+	return "", fmt.Errorf("The value type %T is not supported ", value)
+}
+
+// parseCursorValue restores the key value decoded from the JSON by the type stored in the cursor
+func parseCursorValue(value interface{}, valueType string) (interface{}, error) {
+	switch v := value.(type) {
+	case json.Number:
+		switch valueType {
+		case cursorValueInteger:
+			return v.Int64()
+		case cursorValueFloat:
+			return v.Float64()
+		}
+	case string:
+		switch valueType {
+		case cursorValueString:
+			return v, nil
+		case cursorValueBytes:
+			return base64.StdEncoding.DecodeString(v)
+		case cursorValueTime:
+			return time.Parse(time.RFC3339Nano, v)
+		}
+	case bool:
+		if valueType == cursorValueBool {
+			return v, nil
+		}
+	}
+
+	return nil, fmt.Errorf("The value %v cannot be restored as %s ", value, valueType)
+}
+
+func signCursor(data []byte) []byte {
+	mac := hmac.New(sha256.New, CursorSigningKey)
+	mac.Write(data)
+	return mac.Sum(nil)
+}
+
+func generateCursorSigningKey() []byte {
+	key := make([]byte, 32)
+	_, _ = rand.Read(key)
+	return key
+}
+
+// groupWheres wraps the conditions of the query into the brackets, so the conditions with OR logical operator don't change the meaning of the added condition
+func groupWheres(q QueryInterface) error {
+	if len(q.GetWheres()) < 2 {
+		return nil
+	}
+
+	original, ok := q.(*Query)
+	if !ok {
+		return errors.New("The query with several WHERE conditions can be paginated only if it is created by clients.Query, because its conditions should be grouped ")
+	}
+
+	//The bindings are not changed, because the order of the conditions is the same
+	original.wheres = []query.Where{{First: query.Group(original.wheres)}}
+
+	return nil
+}
+
+// generateSeekWhere generates the condition which selects the rows after the cursor values in the selected order.
+// For the same direction of all columns the row values comparison is used: (a, b) > (?, ?)
+func generateSeekWhere(orderBy []query.OrderByColumn, values []interface{}, backward bool) query.Where {
+	var (
+		isSameDirection = true
+		columns         []string
+		placeholders    []string
+		bindings        []query.Bind
+	)
+	for i, column := range orderBy {
+		if normalizeOrderDirection(column.Direction) != normalizeOrderDirection(orderBy[0].Direction) {
+			isSameDirection = false
+		}
+
+		columns = append(columns, column.Column)
+		placeholders = append(placeholders, "?")
+		bindings = append(bindings, query.Bind{Field: column.Column, Value: values[i]})
+	}
+
+	if isSameDirection {
+		return query.Where{
+			First:    fmt.Sprintf("(%s)", strings.Join(columns, ", ")),
+			Operator: seekOperator(orderBy[0].Direction, backward),
+			Second: query.Expression{
+				SQL:      fmt.Sprintf("(%s)", strings.Join(placeholders, ", ")),
+				Bindings: bindings,
+			},
+		}
+	}
+
+	//For the mixed directions we use the expanded condition: a > ? OR (a = ? AND b < ?)
+	var conditions []query.Where
+	for i, column := range orderBy {
+		var group []query.Where
+		for j := 0; j < i; j++ {
+			group = append(group, query.Eq(orderBy[j].Column, values[j]))
+		}
+
+		group = append(group, query.Where{
+			First:    column.Column,
+			Operator: seekOperator(column.Direction, backward),
+			Second:   query.Bind{Field: column.Column, Value: values[i]},
+		})
+
+		if len(group) == 1 {
+			conditions = append(conditions, group[0])
+			continue
+		}
+
+		conditions = append(conditions, query.And(group...))
+	}
+
+	return query.Or(conditions...)
+}
+
+func seekOperator(direction string, backward bool) string {
+	isAsc := normalizeOrderDirection(direction) == query.OrderDirectionAsc
+	if isAsc != backward {
+		return query.GreaterOperator
+	}
+
+	return query.LessOperator
+}
+
+func reverseOrderDirection(direction string) string {
+	if normalizeOrderDirection(direction) == query.OrderDirectionAsc {
+		return query.OrderDirectionDesc
+	}
+
+	return query.OrderDirectionAsc
+}
+
+func normalizeOrderDirection(direction string) string {
+	if strings.ToUpper(direction) == query.OrderDirectionDesc {
+		return query.OrderDirectionDesc
+	}
+
+	return query.OrderDirectionAsc
+}
+
+// executePage executes the select query for selected page and the COUNT(*) query for the total number of rows
+func executePage(ctx context.Context, c BaseClientInterface, q QueryInterface, page int64, perPage int64) (result dto.PageResult, err error) {
+	if page < 1 || perPage < 1 {
+		return result, errors.New("The page and the number of items per page should be greater than zero ")
+	}
+
+	result = dto.PageResult{
+		Page:    page,
+		PerPage: perPage,
+	}
+
+	countResult, err := c.ExecuteContext(ctx, c.prepareCountQuery(q))
+	if err != nil {
+		return result, err
+	}
+
+	if len(countResult.Items()) > 0 {
+		if result.Total, err = toInt64(countResult.Items()[0].GetField(countColumnAlias).Value); err != nil {
+			return result, err
+		}
+	}
+
+	var offset = (page - 1) * perPage
+	if offset >= result.Total {
+		return result, nil
+	}
+
+	itemsResult, err := c.ExecuteContext(ctx, q.Clone().Limit(query.Limit{
+		Offset: offset,
+		Count:  perPage,
+	}))
+	if err != nil {
+		return result, err
+	}
+
+	result.Items = itemsResult.Items()
+	return result, nil
+}
+
+// prepareCountQuery generates the COUNT(*) query from the select query. The ORDER BY, LIMIT and locking clauses are removed.
+// The grouped, distinct and compound queries are counted using the subquery
+func prepareCountQuery(q QueryInterface) QueryInterface {
+	var (
+		countQuery  = q.Clone().ResetOrderBy().ResetLock().Limit(query.Limit{})
+		countColumn = fmt.Sprintf("COUNT(*) AS %s", countColumnAlias)
+	)
+	if len(q.GetGroupBy()) > 0 || len(q.GetHavings()) > 0 || q.GetDistinct() || len(q.GetCompounds()) > 0 {
+		return new(Query).Select(countColumn).From(SubQuery{
+			Query: countQuery,
+			Alias: countQueryAlias,
+		})
+	}
+
+	return countQuery.ResetColumns().Select(countColumn)
+}
+
+func toInt64(value interface{}) (int64, error) {
+	switch v := value.(type) {
+	case int:
+		return int64(v), nil
+	case int64:
+		return v, nil
+	case string:
+		return strconv.ParseInt(v, 10, 64)
+	case []uint8:
+		return strconv.ParseInt(string(v), 10, 64)
+	}
+
+	return 0, fmt.Errorf("Failed to convert the value %v to the number ", value)
+}
@@ -0,0 +1,328 @@
+package clients
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/sharovik/orm/dto"
+	"github.com/sharovik/orm/query"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGenerateSeekWhere(t *testing.T) {
+	q := new(Query).Select([]interface{}{"id"}).From(&m).Where(generateSeekWhere([]query.OrderByColumn{
+		{Column: "col1", Direction: query.OrderDirectionAsc},
+		{Column: "id", Direction: query.OrderDirectionAsc},
+	}, []interface{}{1, 2}, false))
+	assert.Equal(t, "SELECT id FROM test_table_name WHERE (col1, id) > (?, ?)", SQLiteClient{}.ToSql(q))
+	assert.Equal(t, []query.Bind{{Field: "col1", Value: 1}, {Field: "id", Value: 2}}, q.GetBindings())
+
+	q = new(Query).Select([]interface{}{"id"}).From(&m).Where(generateSeekWhere([]query.OrderByColumn{
+		{Column: "col1", Direction: query.OrderDirectionDesc},
+		{Column: "col2", Direction: query.OrderDirectionAsc},
+		{Column: "id", Direction: query.OrderDirectionAsc},
+	}, []interface{}{1, 2, 3}, true))
+	assert.Equal(t, "SELECT id FROM test_table_name WHERE (col1 > ? OR (col1 = ? AND col2 < ?) OR (col1 = ? AND col2 = ? AND id < ?))", SQLiteClient{}.ToSql(q))
+	assert.Len(t, q.GetBindings(), 6)
+}
+
+func TestPaginate_GroupsConditions(t *testing.T) {
+	var (
+		model = initTestModel("test_table_name")
+		q     = new(Query).Select([]interface{}{"id"}).
+			From(&model).
+			Where(query.Eq("col1", 1)).
+			Where(query.Where{First: "col1", Operator: "=", Second: query.Bind{Field: "col1", Value: 2}, Type: query.WhereOrType}).
+			OrderBy("id", query.OrderDirectionAsc)
+		item = dto.BaseModel{Fields: []interface{}{dto.ModelField{Name: "id", Value: 10}}}
+	)
+
+	cursor, err := KeysetPage{orderBy: q.GetOrderBy()}.encodeCursor(&item, cursorDirectionNext)
+	assert.NoError(t, err)
+
+	page, err := Paginate(q, cursor, 2)
+	assert.NoError(t, err)
+	assert.Equal(t, "SELECT id FROM test_table_name WHERE (col1 = ? OR col1 = ?) AND (id) > (?) ORDER BY id ASC LIMIT 3", SQLiteClient{}.ToSql(page.Query))
+	assert.Equal(t, []query.Bind{
+		{Field: "col1", Value: 1},
+		{Field: "col1", Value: 2},
+		{Field: "id", Value: int64(10)},
+	}, page.Query.GetBindings())
+
+	//The conditions of the original query are not changed
+	assert.Equal(t, "SELECT id FROM test_table_name WHERE col1 = ? OR col1 = ? ORDER BY id ASC", SQLiteClient{}.ToSql(q))
+}
+
+func TestPaginate(t *testing.T) {
+	removeDatabase()
+	initDatabase()
+	defer removeDatabase()
+
+	sqliteClient, err := SQLiteClient{}.Connect(DatabaseConfig{
+		Host: testSQLiteDatabasePath,
+	})
+	assert.NoError(t, err)
+
+	model := initTestModel("testing")
+	_, err = sqliteClient.Execute(new(Query).Create(&model))
+	assert.NoError(t, err)
+
+	//The rows ordered by col1 DESC, id ASC: 2, 4, 1, 3, 5
+	for _, value := range []int{1, 2, 1, 2, 1} {
+		model.AddModelField(dto.ModelField{Name: "col1", Value: value})
+		_, err = sqliteClient.Execute(new(Query).Insert(&model))
+		assert.NoError(t, err)
+	}
+
+	q := new(Query).Select([]interface{}{"id", "col1"}).
+		From(&model).
+		OrderBy("col1", query.OrderDirectionDesc).
+		OrderBy("id", query.OrderDirectionAsc)
+
+	fetch := func(cursor string) (ids []int, next string, previous string) {
+		page, err := Paginate(q, cursor, 2)
+		assert.NoError(t, err)
+
+		res, err := sqliteClient.Execute(page.Query)
+		assert.NoError(t, err)
+
+		items, next, previous, err := page.Result(res)
+		assert.NoError(t, err)
+		for _, item := range items {
+			ids = append(ids, item.GetField("id").Value.(int))
+		}
+
+		return ids, next, previous
+	}
+
+	ids, next, previous := fetch("")
+	assert.Equal(t, []int{2, 4}, ids)
+	assert.NotEmpty(t, next)
+	assert.Empty(t, previous)
+
+	ids, next, previous = fetch(next)
+	assert.Equal(t, []int{1, 3}, ids)
+	assert.NotEmpty(t, next)
+	assert.NotEmpty(t, previous)
+
+	ids, lastNext, lastPrevious := fetch(next)
+	assert.Equal(t, []int{5}, ids)
+	assert.Empty(t, lastNext)
+	assert.NotEmpty(t, lastPrevious)
+
+	ids, next, previous = fetch(lastPrevious)
+	assert.Equal(t, []int{1, 3}, ids)
+	assert.NotEmpty(t, next)
+	assert.NotEmpty(t, previous)
+
+	ids, next, previous = fetch(previous)
+	assert.Equal(t, []int{2, 4}, ids)
+	assert.NotEmpty(t, next)
+	assert.Empty(t, previous)
+
+	//The original query should not be changed
+	assert.Equal(t, "SELECT id, col1 FROM testing ORDER BY col1 DESC, id ASC", sqliteClient.ToSql(q))
+
+	//The modified cursor should be rejected
+	parts := strings.Split(next, ".")
+	_, err = Paginate(q, parts[0]+"x."+parts[1], 2)
+	assert.ErrorIs(t, err, ErrInvalidCursor)
+
+	_, err = Paginate(new(Query).Select([]interface{}{"id"}).From(&model).OrderBy("id", query.OrderDirectionAsc), next, 2)
+	assert.ErrorIs(t, err, ErrInvalidCursor)
+
+	_, err = Paginate(new(Query).Select([]interface{}{"id"}).From(&model), "", 2)
+	assert.Error(t, err)
+}
+
+func TestPaginate_TypedKeys(t *testing.T) {
+	removeDatabase()
+	initDatabase()
+	defer removeDatabase()
+
+	sqliteClient, err := SQLiteClient{}.Connect(DatabaseConfig{
+		Host: testSQLiteDatabasePath,
+	})
+	assert.NoError(t, err)
+
+	_, err = sqliteClient.GetClient().Exec("CREATE TABLE events (id INTEGER PRIMARY KEY, created DATETIME NOT NULL, score REAL NOT NULL)")
+	assert.NoError(t, err)
+
+	//The rows ordered by created: 3, 1, 4, 2, 5 and by score: 2, 5, 1, 3, 4
+	start := time.Date(2024, 1, 1, 10, 0, 0, 500, time.UTC)
+	for _, row := range []struct {
+		created time.Duration
+		score   float64
+	}{
+		{created: time.Hour, score: 1.5},
+		{created: 3 * time.Hour, score: 0.25},
+		{created: 0, score: 2.75},
+		{created: 2 * time.Hour, score: 3.125},
+		{created: 4 * time.Hour, score: 0.5},
+	} {
+		_, err = sqliteClient.GetClient().Exec("INSERT INTO events (created, score) VALUES (?, ?)", start.Add(row.created), row.score)
+		assert.NoError(t, err)
+	}
+
+	fetchAll := func(column string) (ids []int) {
+		q := new(Query).Select([]interface{}{"id", column}).
+			From("events").
+			OrderBy(column, query.OrderDirectionAsc)
+
+		var cursor string
+		for {
+			page, err := Paginate(q, cursor, 2)
+			assert.NoError(t, err)
+
+			res, err := sqliteClient.Execute(page.Query)
+			assert.NoError(t, err)
+
+			items, next, _, err := page.Result(res)
+			assert.NoError(t, err)
+			for _, item := range items {
+				ids = append(ids, item.GetField("id").Value.(int))
+			}
+
+			if next == "" {
+				return ids
+			}
+
+			cursor = next
+		}
+	}
+
+	assert.Equal(t, []int{3, 1, 4, 2, 5}, fetchAll("created"))
+	assert.Equal(t, []int{2, 5, 1, 3, 4}, fetchAll("score"))
+
+	//The key values are restored with the same type
+	orderBy := []query.OrderByColumn{{Column: "created"}, {Column: "score"}}
+	cursor, err := KeysetPage{orderBy: orderBy}.encodeCursor(&dto.BaseModel{Fields: []interface{}{
+		dto.ModelField{Name: "created", Value: start},
+		dto.ModelField{Name: "score", Value: 2.0},
+	}}, cursorDirectionNext)
+	assert.NoError(t, err)
+
+	payload, err := decodeCursor(cursor, orderBy)
+	assert.NoError(t, err)
+	assert.Equal(t, []interface{}{start, 2.0}, payload.Values)
+
+	//The NULL values and the unsupported types cannot be used as the keys
+	for _, value := range []interface{}{nil, struct{}{}} {
+		_, err = KeysetPage{orderBy: orderBy[:1]}.encodeCursor(&dto.BaseModel{Fields: []interface{}{
+			dto.ModelField{Name: "created", Value: value},
+		}}, cursorDirectionNext)
+		assert.Error(t, err)
+	}
+}
+
+func TestPaginate_CustomQuery(t *testing.T) {
+	var (
+		model = initTestModel("test_table_name")
+		item  = dto.BaseModel{Fields: []interface{}{dto.ModelField{Name: "id", Value: 10}}}
+		q     = new(Query).Select([]interface{}{"id"}).From(&model).OrderBy("id", query.OrderDirectionAsc)
+	)
+
+	cursor, err := KeysetPage{orderBy: q.GetOrderBy()}.encodeCursor(&item, cursorDirectionNext)
+	assert.NoError(t, err)
+
+	//The single condition does not need to be grouped
+	_, err = Paginate(testWrappedQuery{Query: q.Where(query.Eq("col1", 1)).(*Query)}, cursor, 2)
+	assert.NoError(t, err)
+
+	//The several conditions of the custom query implementation cannot be grouped
+	_, err = Paginate(testWrappedQuery{Query: q.Where(query.Eq("col2", 2)).(*Query)}, cursor, 2)
+	assert.Error(t, err)
+}
+
+func TestPrepareCountQuery(t *testing.T) {
+	q := new(Query).Select([]interface{}{"id", "col1"}).
+		From(&m).
+		Where(query.Eq("col1", 1)).
+		OrderBy("id", query.OrderDirectionAsc).
+		Limit(query.Limit{Offset: 10, Count: 5})
+	assert.Equal(t, "SELECT COUNT(*) AS total FROM test_table_name WHERE col1 = ?", SQLiteClient{}.ToSql(prepareCountQuery(q)))
+	assert.Equal(t, q.GetBindings(), prepareCountQuery(q).GetBindings())
+	assert.Equal(t, "SELECT id, col1 FROM test_table_name WHERE col1 = ? ORDER BY id ASC LIMIT 5 OFFSET 10", SQLiteClient{}.ToSql(q))
+
+	//The locking clause is removed too
+	q.ForUpdate()
+	assert.Equal(t, "SELECT COUNT(*) AS total FROM test_table_name WHERE col1 = ?", MySQLClient{}.ToSql(prepareCountQuery(q)))
+	assert.Equal(t, "SELECT id, col1 FROM test_table_name WHERE col1 = ? ORDER BY id ASC LIMIT 5 OFFSET 10 FOR UPDATE", MySQLClient{}.ToSql(q))
+
+	q = new(Query).Select([]interface{}{"relation_id", "COUNT(id)"}).
+		From(&m).
+		Where(query.Eq("col1", 1)).
+		GroupBy("relation_id").
+		OrderBy("relation_id", query.OrderDirectionAsc)
+	assert.Equal(t, "SELECT COUNT(*) AS total FROM (SELECT relation_id, COUNT(id) FROM test_table_name WHERE col1 = ? GROUP BY relation_id) AS count_query", MySQLClient{}.ToSql(prepareCountQuery(q)))
+	assert.Equal(t, q.GetBindings(), prepareCountQuery(q).GetBindings())
+
+	//The emulated FULL OUTER JOIN of MySQL is counted using the subquery
+	q = new(Query).Select([]interface{}{"test_table_name.id"}).
+		From(&m).
+		Join(query.Join{
+			Target:    query.Reference{Table: "test_table_name2", Key: "relation_id"},
+			With:      query.Reference{Table: "test_table_name", Key: "id"},
+			Condition: "=",
+			Type:      query.FullJoinType,
+		}).
+		Where(query.Eq("test_table_name.col1", 1)).
+		OrderBy("test_table_name.id", query.OrderDirectionAsc)
+	for _, countQuery := range []QueryInterface{MySQLClient{}.prepareCountQuery(q), MySQLClient{}.prepareCountQuery(q.Clone().Distinct())} {
+		assert.NoError(t, MySQLClient{}.validateQuery(countQuery))
+		assert.Equal(t, []query.Bind{
+			{Field: "test_table_name.col1", Value: 1},
+			{Field: "test_table_name.col1", Value: 1},
+		}, MySQLClient{}.prepareBindings(countQuery))
+	}
+
+	assert.Equal(t, "SELECT COUNT(*) AS total FROM (SELECT DISTINCT test_table_name.id FROM test_table_name LEFT JOIN test_table_name2 ON (test_table_name2.relation_id = test_table_name.id) WHERE test_table_name.col1 = ? UNION SELECT DISTINCT test_table_name.id FROM test_table_name RIGHT JOIN test_table_name2 ON (test_table_name2.relation_id = test_table_name.id) WHERE test_table_name.col1 = ? AND test_table_name.id IS NULL) AS count_query", MySQLClient{}.ToSql(MySQLClient{}.prepareCountQuery(q.Clone().Distinct())))
+	assert.Equal(t, "SELECT COUNT(*) AS total FROM test_table_name FULL OUTER JOIN test_table_name2 ON (test_table_name2.relation_id = test_table_name.id) WHERE test_table_name.col1 = ?", SQLiteClient{}.ToSql(SQLiteClient{}.prepareCountQuery(q)))
+}
+
+func TestSQLiteClient_Page(t *testing.T) {
+	removeDatabase()
+	initDatabase()
+	defer removeDatabase()
+
+	sqliteClient, err := SQLiteClient{}.Connect(DatabaseConfig{
+		Host: testSQLiteDatabasePath,
+	})
+	assert.NoError(t, err)
+
+	model := initTestModel("testing")
+	_, err = sqliteClient.Execute(new(Query).Create(&model))
+	assert.NoError(t, err)
+
+	for _, value := range []int{1, 2, 1, 2, 1} {
+		model.AddModelField(dto.ModelField{Name: "col1", Value: value})
+		_, err = sqliteClient.Execute(new(Query).Insert(&model))
+		assert.NoError(t, err)
+	}
+
+	q := new(Query).Select([]interface{}{"id"}).From(&model).OrderBy("id", query.OrderDirectionAsc)
+	result, err := sqliteClient.Page(context.Background(), q, 2, 2)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(5), result.Total)
+	assert.Equal(t, int64(3), result.TotalPages())
+	assert.Len(t, result.Items, 2)
+	assert.Equal(t, 3, result.Items[0].GetField("id").Value)
+	assert.Equal(t, 4, result.Items[1].GetField("id").Value)
+
+	result, err = sqliteClient.Page(context.Background(), q, 4, 2)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(5), result.Total)
+	assert.Empty(t, result.Items)
+
+	grouped := new(Query).Select([]interface{}{"col1", "COUNT(id) AS cnt"}).From(&model).GroupBy("col1").OrderBy("col1", query.OrderDirectionDesc)
+	result, err = sqliteClient.Page(context.Background(), grouped, 1, 1)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(2), result.Total)
+	assert.Len(t, result.Items, 1)
+	assert.Equal(t, 2, result.Items[0].GetField("cnt").Value)
+
+	_, err = sqliteClient.Page(context.Background(), q, 0, 2)
+	assert.Error(t, err)
+}
@@ -1,20 +1,26 @@
 package clients
 
 import (
+	"context"
 	"database/sql"
 	"errors"
 	"fmt"
 	"os"
+	"regexp"
+	"slices"
+	"strconv"
 	"strings"
 
-	_ "github.com/mattn/go-sqlite3"
+	"github.com/mattn/go-sqlite3"
 	"github.com/sharovik/orm/dto"
+	"github.com/sharovik/orm/query"
 )
 
 // SQLiteClient the SQLite client
 type SQLiteClient struct {
 	Client *sql.DB
 	Config DatabaseConfig
+	conn   *sql.Conn
 }
 
 func (c SQLiteClient) Connect(config DatabaseConfig) (client BaseClientInterface, err error) {
@@ -33,6 +39,10 @@ func (c SQLiteClient) Connect(config DatabaseConfig) (client BaseClientInterface
 }
 
 func (c SQLiteClient) Disconnect() error {
+	if c.conn != nil {
+		return c.conn.Close()
+	}
+
 	return c.Client.Close()
 }
 
@@ -40,7 +50,36 @@ func (c SQLiteClient) GetClient() *sql.DB {
 	return c.Client
 }
 
+// Session returns the client, which executes all queries using the same connection of the pool. The Disconnect method of the session returns the connection to the pool
+func (c SQLiteClient) Session(ctx context.Context) (client BaseClientInterface, err error) {
+	if c.conn != nil {
+		return c, errors.New("The session is already started for this client ")
+	}
+
+	if c.conn, err = c.GetClient().Conn(ctx); err != nil {
+		return c, err
+	}
+
+	return c, nil
+}
+
+// executor returns the connection of the session or the pool of the connections
+func (c SQLiteClient) executor() executor {
+	if c.conn != nil {
+		return c.conn
+	}
+
+	return c.Client
+}
+
+// ToSql returns the generated query string. For the ALTER query, which rebuilds the table, the connected client adds the existing columns, foreign keys and constraints of the table, the same as during the execution
 func (c SQLiteClient) ToSql(q QueryInterface) string {
+	if q.GetQueryType() == AlterType && isNewSchemaShouldBeGenerated(q) && (c.Client != nil || c.conn != nil) {
+		if rebuilt, err := c.prepareRebuildQuery(context.Background(), q); err == nil {
+			return toSql(c, rebuilt)
+		}
+	}
+
 	return toSql(c, q)
 }
 
@@ -56,76 +95,323 @@ func (c SQLiteClient) prepareTransactionRollback() string {
 	return "ROLLBACK;"
 }
 
+// validateQuery method checks if the query can be executed by the SQLite database
+func (c SQLiteClient) validateQuery(q QueryInterface) error {
+	if err := validateViewQuery(q); err != nil {
+		return err
+	}
+
+	if err := validatePrimaryKeyValues(q); err != nil {
+		return err
+	}
+
+	if err := validateLimit(q); err != nil {
+		return err
+	}
+
+	if err := validateJoinedRowsQuery(q); err != nil {
+		return err
+	}
+
+	if hasColumnsOnUpdate(q) {
+		return errors.New("The ON UPDATE clause of the column is not supported by SQLite. Please use the trigger to update the column ")
+	}
+
+	if hasStoredColumnsToAdd(q) {
+		return errors.New("The STORED generated column cannot be added by ALTER TABLE ... ADD COLUMN statement in SQLite. Please add the VIRTUAL generated column or define the column in the CREATE TABLE statement ")
+	}
+
+	var (
+		isWindow    = hasWindowFunctions(q)
+		isFullJoin  = countJoins(q.GetJoins(), query.FullJoinType) > 0
+		isGenerated = hasGeneratedColumns(q)
+		isStrict    = q.GetQueryType() == CreateType && q.GetDestination() != nil && q.GetDestination().GetTableOptions().Strict
+	)
+	if !isWindow && !isFullJoin && !isGenerated && !isStrict {
+		return nil
+	}
+
+	var version string
+	if err := c.executor().QueryRowContext(context.Background(), "SELECT sqlite_version()").Scan(&version); err != nil {
+		return err
+	}
+
+	if isWindow && !isVersionAtLeast(version, []int{3, 25, 0}) {
+		return fmt.Errorf("Window functions are not supported by the SQLite version %s. These functions are available since SQLite 3.25 ", version)
+	}
+
+	if isFullJoin && !isVersionAtLeast(version, []int{3, 39, 0}) {
+		return fmt.Errorf("FULL OUTER JOIN is not supported by the SQLite version %s. This join is available since SQLite 3.39 ", version)
+	}
+
+	if isGenerated && !isVersionAtLeast(version, []int{3, 31, 0}) {
+		return fmt.Errorf("Generated columns are not supported by the SQLite version %s. These columns are available since SQLite 3.31 ", version)
+	}
+
+	if isStrict && !isVersionAtLeast(version, []int{3, 37, 0}) {
+		return fmt.Errorf("STRICT tables are not supported by the SQLite version %s. These tables are available since SQLite 3.37 ", version)
+	}
+
+	return nil
+}
+
+// prepareBindings method returns the bindings in the order of the placeholders of the generated query
+func (c SQLiteClient) prepareBindings(q QueryInterface) []query.Bind {
+	return q.GetBindings()
+}
+
+// prepareSelectQuery method prepares the select query statement.
+// SQLite does not support the row locking clauses, because it locks the whole database, so FOR UPDATE, FOR SHARE, NOWAIT and SKIP LOCKED are ignored.
+// Please use the BEGIN IMMEDIATE transaction to acquire the write lock before the selection
+func (c SQLiteClient) prepareSelectQuery(q QueryInterface) string {
+	return prepareSelectQuery(q)
+}
+
+// prepareUpdateQuery method prepares the update query statement.
+// SQLite does not support the joins in the UPDATE statement, so the joined rows are selected by the subquery: UPDATE table SET ... WHERE id IN (SELECT table.id FROM table JOIN ...)
+func (c SQLiteClient) prepareUpdateQuery(q QueryInterface) string {
+	if len(q.GetJoins()) == 0 {
+		return prepareUpdateQuery(q)
+	}
+
+	queryStr := generateWithStr(q)
+	queryStr += fmt.Sprintf("UPDATE %s SET %s WHERE %s", q.GetDestination().GetTableName(), generateAssignmentsStr(q.GetColumns()), generateJoinedRowsConditionStr(q))
+
+	return queryStr
+}
+
+// prepareDeleteQuery method prepares the delete query statement.
+// SQLite does not support the joins in the DELETE statement, so the joined rows are selected by the subquery: DELETE FROM table WHERE id IN (SELECT table.id FROM table JOIN ...)
+func (c SQLiteClient) prepareDeleteQuery(q QueryInterface) string {
+	if len(q.GetJoins()) == 0 {
+		return prepareDeleteQuery(q)
+	}
+
+	queryStr := generateWithStr(q)
+	queryStr += fmt.Sprintf("DELETE FROM %s WHERE %s", q.GetDestination().GetTableName(), generateJoinedRowsConditionStr(q))
+
+	return queryStr
+}
+
+// generateJoinedRowsConditionStr generates the condition, which selects the rows of the destination table matched by the joins and the where clauses.
+// For the composite primary key the row values are compared: (k1, k2) IN (SELECT table.k1, table.k2 ...). If the primary key is not specified, the rowid is used
+func generateJoinedRowsConditionStr(q QueryInterface) string {
+	var (
+		table   = q.GetDestination().GetTableName()
+		keys    = getPrimaryKeysNames(q.GetDestination())
+		columns []string
+	)
+	if len(keys) == 0 {
+		keys = []string{"rowid"}
+	}
+
+	for _, key := range keys {
+		columns = append(columns, fmt.Sprintf("%s.%s", table, key))
+	}
+
+	queryStr := fmt.Sprintf("SELECT %s FROM %s %s", strings.Join(columns, ", "), table, generateJoinsStr(q.GetJoins()))
+
+	if len(q.GetWheres()) > 0 {
+		queryStr += fmt.Sprintf(" %s", generateWhereStr(q.GetWheres()))
+	}
+
+	if len(q.GetGroupBy()) > 0 {
+		queryStr += fmt.Sprintf(" %s", generateGroupByStr(q.GetGroupBy()))
+	}
+
+	if len(q.GetOrderBy()) > 0 {
+		queryStr += fmt.Sprintf(" %s", generateOrderByStr(q.GetOrderBy()))
+	}
+
+	if q.GetLimit() != *new(query.Limit) {
+		queryStr += fmt.Sprintf(" %s", generateLimitStr(q.GetLimit()))
+	}
+
+	if len(keys) > 1 {
+		return fmt.Sprintf("(%s) IN (%s)", strings.Join(keys, ", "), queryStr)
+	}
+
+	return fmt.Sprintf("%s IN (%s)", keys[0], queryStr)
+}
+
+// hasStoredColumnsToAdd checks if the STORED generated columns are added by ALTER TABLE ... ADD COLUMN statement. The columns added during the table rebuild are defined in the CREATE TABLE statement, so they are allowed
+func hasStoredColumnsToAdd(q QueryInterface) bool {
+	if q.GetQueryType() != AlterType || isNewSchemaShouldBeGenerated(q) {
+		return false
+	}
+
+	for _, column := range q.GetColumns() {
+		if v, ok := column.(dto.ModelField); ok && v.Generated != "" && strings.EqualFold(v.GeneratedType, dto.StoredGeneratedType) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// validateJoinedRowsQuery checks if the rows of the UPDATE or DELETE query with the joins can be selected by the subquery.
+// The WITHOUT ROWID table does not have the rowid, so the primary key of the model is required
+func validateJoinedRowsQuery(q QueryInterface) error {
+	if (q.GetQueryType() != UpdateType && q.GetQueryType() != DeleteType) || len(q.GetJoins()) == 0 || q.GetDestination() == nil {
+		return nil
+	}
+
+	if q.GetDestination().GetTableOptions().WithoutRowID && len(q.GetDestination().GetPrimaryKeys()) == 0 {
+		return fmt.Errorf("The joined rows of the WITHOUT ROWID table %s are selected by the primary key, so the primary key should be specified in the model ", q.GetDestination().GetTableName())
+	}
+
+	return nil
+}
+
 // prepareCreateSQLQuery method prepares the create query statement
 func (c SQLiteClient) prepareCreateQuery(q QueryInterface) string {
-	ifNotExists := ""
-	if q.GetIfNotExists() {
-		ifNotExists = "IF NOT EXISTS "
+	return c.prepareCreateTableQuery(q, q.GetDestination().GetTableName())
+}
+
+// prepareCreateTableQuery method prepares the create query statement. The name of the primary key constraint is generated using the keyTable,
+// so the temp table of the rebuild keeps the name of the original primary key
+func (c SQLiteClient) prepareCreateTableQuery(q QueryInterface, keyTable string) string {
+	if selectQuery, ok := q.GetValues().(QueryInterface); ok {
+		return fmt.Sprintf("%s AS %s;", generateCreateTableStr(q), c.prepareSelectQuery(selectQuery))
 	}
 
-	queryStr := fmt.Sprintf("CREATE TABLE %s%s (", ifNotExists, q.GetDestination().GetTableName())
+	queryStr := fmt.Sprintf("%s (", generateCreateTableStr(q))
 
-	if q.GetDestination().GetPrimaryKey() != *(new(dto.ModelField)) {
-		queryStr += fmt.Sprintf("%s %s CONSTRAINT %s_pk primary key", q.GetDestination().GetPrimaryKey().Name, q.GetDestination().GetPrimaryKey().Type, q.GetDestination().GetTableName())
+	var (
+		definitions []string
+		isComposite = len(q.GetDestination().GetPrimaryKeys()) > 1
+	)
+	if q.GetDestination().GetPrimaryKey() != *(new(dto.ModelField)) && !isComposite {
+		primaryKeyStr := fmt.Sprintf("%s %s CONSTRAINT %s_pk primary key", q.GetDestination().GetPrimaryKey().Name, q.GetDestination().GetPrimaryKey().Type, keyTable)
 		if q.GetDestination().GetPrimaryKey().AutoIncrement {
-			queryStr += " autoincrement"
+			primaryKeyStr += " autoincrement"
 		}
+
+		definitions = append(definitions, primaryKeyStr)
 	}
 
 	if len(q.GetDestination().GetColumns()) > 0 {
-		queryStr += fmt.Sprintf(", %s", generateColumnsWithTypesStr(q.GetDestination().GetColumns()))
+		definitions = append(definitions, generateColumnsWithTypesStr(prepareCreateColumns(q.GetDestination())))
+	}
+
+	queryStr += strings.Join(definitions, ", ")
+
+	if isComposite {
+		queryStr += fmt.Sprintf(",\nCONSTRAINT %s_pk PRIMARY KEY (%s)", keyTable, strings.Join(getPrimaryKeysNames(q.GetDestination()), ", "))
+	}
+
+	if len(q.GetConstraintsToAdd()) > 0 {
+		queryStr += fmt.Sprintf(",\n%s", generateConstraintsStr(q.GetConstraintsToAdd()))
 	}
 
 	if len(q.GetForeignKeysToAdd()) > 0 {
 		queryStr += fmt.Sprintf(",\n%s", generateForeignKeysStr(q.GetForeignKeysToAdd()))
 	}
 
-	queryStr += ");"
+	queryStr += ")"
+
+	var options []string
+	if q.GetDestination().GetTableOptions().Strict {
+		options = append(options, "STRICT")
+	}
+
+	if q.GetDestination().GetTableOptions().WithoutRowID {
+		options = append(options, "WITHOUT ROWID")
+	}
+
+	if len(options) > 0 {
+		queryStr += fmt.Sprintf(" %s", strings.Join(options, ", "))
+	}
 
 	if len(q.GetIndexesToAdd()) > 0 {
-		queryStr += fmt.Sprintf(" %s", generateIndexesStr(q.GetIndexesToAdd()))
+		var indexes []dto.Index
+		for _, index := range q.GetIndexesToAdd() {
+			//The indexes of the table, which is created only if it does not exist, should be created in the same way
+			index.IfNotExists = index.IfNotExists || q.GetIfNotExists()
+			indexes = append(indexes, index)
+		}
+
+		queryStr += fmt.Sprintf(";\n%s", generateIndexesStr(q.GetDestination().GetTableName(), indexes))
+	}
+
+	return queryStr + ";"
+}
+
+// prepareCountQuery method prepares the COUNT(*) query for the pagination
+func (c SQLiteClient) prepareCountQuery(q QueryInterface) QueryInterface {
+	return prepareCountQuery(q)
+}
+
+// prepareTruncateQuery method prepares the statement, which removes all rows of the table. SQLite does not support TRUNCATE statement, so DELETE statement is used.
+// For the table with AUTOINCREMENT primary key the sequence is removed from sqlite_sequence table too, so the new rows start from the first ID
+func (c SQLiteClient) prepareTruncateQuery(q QueryInterface) string {
+	var (
+		table    = q.GetDestination().GetTableName()
+		queryStr = fmt.Sprintf("DELETE FROM %s", table)
+	)
+	if q.GetDestination().GetPrimaryKey().AutoIncrement {
+		queryStr += fmt.Sprintf(";\nDELETE FROM sqlite_sequence WHERE name = '%s'", strings.ReplaceAll(table, "'", "''"))
 	}
 
 	return queryStr
 }
 
+// prepareCreateViewQuery method prepares the create view statement. SQLite does not support CREATE OR REPLACE VIEW statement, so the existing view is dropped before the creation
+func (c SQLiteClient) prepareCreateViewQuery(q QueryInterface) string {
+	if q.GetViewQuery() == nil {
+		return ""
+	}
+
+	var queryStr string
+	if q.GetOrReplace() {
+		queryStr = fmt.Sprintf("DROP VIEW IF EXISTS %s;\n", q.GetDestination().GetTableName())
+	}
+
+	return queryStr + fmt.Sprintf("CREATE VIEW %s AS %s", q.GetDestination().GetTableName(), c.prepareSelectQuery(q.GetViewQuery()))
+}
+
+// prepareCreateTriggerQuery method prepares the create trigger statement
+func (c SQLiteClient) prepareCreateTriggerQuery(q QueryInterface) string {
+	return fmt.Sprintf("CREATE TRIGGER %s %s;", generateTriggerStr(q.GetTrigger()), generateTriggerBodyStr(q.GetTrigger().Body))
+}
+
 // prepareAlterSQLStr method prepares the alter query statement
 func (c SQLiteClient) prepareAlterQuery(q QueryInterface) string {
 	var queryStr = ""
 
 	if isNewSchemaShouldBeGenerated(q) {
+		var (
+			table = q.GetDestination().GetTableName()
+			qb    = buildTempTableSQLiteQuery(q)
+		)
+
 		//We first generate the "create" statement for the new table
-		qb := buildTempTableSQLiteQuery(q)
-		queryStr = fmt.Sprintf("%s\n", c.prepareCreateQuery(qb))
+		queryStr = fmt.Sprintf("%s\n", c.prepareCreateTableQuery(qb, table))
 
-		//Then we insert the data from the old table into the new table
-		var selectColumns []interface{}
-		for _, column := range qb.GetDestination().GetColumns() {
+		//Then we copy the data from the old table into the new table. The renamed columns are selected using their original names
+		var insertColumns, selectColumns []string
+		for _, column := range append([]interface{}{qb.GetDestination().GetPrimaryKey()}, qb.GetDestination().GetColumns()...) {
 			switch v := column.(type) {
 			case dto.ModelField:
-				if v.AutoIncrement {
+				//The values of the generated columns are computed by the database
+				if v.Name == "" || v.Generated != "" || isColumnAdded(q, v.Name) || slices.Contains(insertColumns, v.Name) {
 					break
 				}
 
-				selectColumns = append(selectColumns, v.Name)
+				insertColumns = append(insertColumns, v.Name)
+				selectColumns = append(selectColumns, getRenamedColumnSource(q, v.Name))
 			}
 		}
-		selQb := (new(Query)).Select(selectColumns).From(q.GetDestination())
-		inQb := (new(Query)).Insert(qb.GetDestination()).Values(selQb)
-		queryStr += fmt.Sprintf("%s;\n", prepareInsertQuery(inQb))
+		queryStr += fmt.Sprintf("INSERT INTO %s (%s) SELECT %s FROM %s;\n", qb.GetDestination().GetTableName(), strings.Join(insertColumns, ", "), strings.Join(selectColumns, ", "), table)
 
-		//Now we need to switch the names of the new and the old tables
-		queryStr += fmt.Sprintf("%s;\n", prepareRenameTableQuery(new(Query).
-			Rename(q.GetDestination().GetTableName(), fmt.Sprintf("%s%s", OldTablePrefix, q.GetDestination().GetTableName())),
-		))
-		queryStr += fmt.Sprintf("%s;\n", prepareRenameTableQuery(new(Query).
-			Rename(qb.GetDestination().GetTableName(), q.GetDestination().GetTableName()),
-		))
+		//Now we drop the old table and the new table takes its name
+		queryStr += fmt.Sprintf("%s;\n", prepareDropQuery(new(Query).Drop(&dto.BaseModel{TableName: table})))
+		queryStr += fmt.Sprintf("%s;", prepareRenameTableQuery(new(Query).Rename(qb.GetDestination().GetTableName(), table)))
 
-		//We drop the old table
-		queryStr += fmt.Sprintf("%s;", prepareDropQuery(new(Query).Drop(&dto.BaseModel{
-			TableName: fmt.Sprintf("%s%s", OldTablePrefix, q.GetDestination().GetTableName()),
-		})))
+		//The new indexes are created for the renamed table. The existing indexes, triggers and views are recreated during the execution, see executeTableRebuild
+		if len(q.GetIndexesToAdd()) > 0 {
+			queryStr += fmt.Sprintf("\n%s;", generateIndexesStr(table, q.GetIndexesToAdd()))
+		}
 
 		return queryStr
 	}
@@ -142,70 +428,321 @@ func (c SQLiteClient) prepareAlterQuery(q QueryInterface) string {
 	}
 
 	//Generate indexes to add
-	if len(q.GetIndexesToAdd()) > 0 {
-		for _, column := range q.GetIndexesToAdd() {
-			str := "CREATE"
-			if column.Unique {
-				str += " UNIQUE"
-			}
+	for _, index := range q.GetIndexesToAdd() {
+		result = append(result, generateIndexStr(q.GetDestination().GetTableName(), index))
+	}
+
+	//Generate indexes to drop
+	for _, index := range q.GetIndexesToDrop() {
+		result = append(result, generateDropIndexStr(index))
+	}
+
+	if len(result) > 0 {
+		queryStr += strings.Join(result, ";\n")
+	}
 
-			str += " INDEX"
-			if column.Name != "" {
-				str += fmt.Sprintf(" %s", column.Name)
+	return queryStr
+}
+
+// Constraints returns the PRIMARY KEY, UNIQUE and CHECK constraints of the table. The table-level UNIQUE and CHECK constraints are parsed from the CREATE TABLE statement stored in sqlite_master table
+func (c SQLiteClient) Constraints(ctx context.Context, table string) (constraints []dto.Constraint, err error) {
+	createStr, err := c.selectCreateTableStr(ctx, table)
+	if err != nil {
+		return nil, err
+	}
+
+	var primaryKeyName string
+	for _, definition := range splitSQLiteDefinitions(createStr) {
+		if matches := sqliteInlinePrimaryKeyRegexp.FindStringSubmatch(definition); matches != nil {
+			primaryKeyName = unquoteSQLiteIdentifier(matches[1])
+		}
+
+		matches := sqliteConstraintRegexp.FindStringSubmatch(definition)
+		if matches == nil {
+			continue
+		}
+
+		constraint := dto.Constraint{
+			Name: unquoteSQLiteIdentifier(matches[1]),
+			Type: strings.ToUpper(strings.Join(strings.Fields(matches[2]), " ")),
+		}
+
+		switch constraint.Type {
+		case dto.PrimaryKeyConstraint:
+			primaryKeyName = constraint.Name
+			continue
+		case dto.CheckConstraint:
+			constraint.Expression = strings.TrimSpace(matches[3])
+		default:
+			for _, column := range splitSQLiteDefinitions(fmt.Sprintf("(%s)", matches[3])) {
+				constraint.Columns = append(constraint.Columns, unquoteSQLiteIdentifier(strings.Fields(column)[0]))
 			}
+		}
+
+		constraints = append(constraints, constraint)
+	}
+
+	//The columns of the primary key are selected in the order of the key
+	rows, err := c.executor().QueryContext(ctx, "SELECT name FROM pragma_table_info(?) WHERE pk > 0 ORDER BY pk", table)
+	if err != nil {
+		return nil, err
+	}
 
-			str += fmt.Sprintf(" on %s (%s)", q.GetDestination().GetTableName(), column.Key)
-			result = append(result, str)
+	defer rows.Close()
+
+	var primaryKey []dto.Constraint
+	for rows.Next() {
+		var column string
+		if err = rows.Scan(&column); err != nil {
+			return nil, err
 		}
+
+		primaryKey = appendConstraintColumn(primaryKey, primaryKeyName, dto.PrimaryKeyConstraint, column)
 	}
 
-	//Generate indexes to add
-	if len(q.GetIndexesToDrop()) > 0 {
-		for _, column := range q.GetIndexesToDrop() {
-			key := column.Name
-			if key == "" {
-				key = column.Key
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return append(primaryKey, constraints...), nil
+}
+
+// foreignKeys returns the foreign keys of the table. The names of the foreign keys are parsed from the CREATE TABLE statement stored in sqlite_master table
+func (c SQLiteClient) foreignKeys(ctx context.Context, table string) (foreignKeys []dto.ForeignKey, err error) {
+	createStr, err := c.selectCreateTableStr(ctx, table)
+	if err != nil {
+		return nil, err
+	}
+
+	var names = map[string]string{}
+	for _, definition := range splitSQLiteDefinitions(createStr) {
+		if matches := sqliteForeignKeyRegexp.FindStringSubmatch(definition); matches != nil {
+			names[strings.ToLower(joinSQLiteIdentifiers(matches[2]))] = unquoteSQLiteIdentifier(matches[1])
+		}
+	}
+
+	//The foreign keys are listed in the reverse order of their definition. The columns of the composite foreign key have the same id
+	rows, err := c.executor().QueryContext(ctx, `SELECT id, "table", "from", COALESCE("to", ''), on_update, on_delete FROM pragma_foreign_key_list(?) ORDER BY id DESC, seq`, table)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	var lastID int64 = -1
+	for rows.Next() {
+		var (
+			id                                   int64
+			target, from, to, onUpdate, onDelete string
+		)
+		if err = rows.Scan(&id, &target, &from, &to, &onUpdate, &onDelete); err != nil {
+			return nil, err
+		}
+
+		if id != lastID {
+			lastID = id
+			foreignKeys = append(foreignKeys, dto.ForeignKey{
+				Target:   query.Reference{Table: target},
+				With:     query.Reference{Table: table},
+				OnDelete: onDelete,
+				OnUpdate: onUpdate,
+			})
+		}
+
+		foreignKey := &foreignKeys[len(foreignKeys)-1]
+		foreignKey.With.Key = joinSQLiteIdentifiers(strings.TrimPrefix(fmt.Sprintf("%s, %s", foreignKey.With.Key, from), ", "))
+		if to != "" {
+			foreignKey.Target.Key = joinSQLiteIdentifiers(strings.TrimPrefix(fmt.Sprintf("%s, %s", foreignKey.Target.Key, to), ", "))
+		}
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	for i, foreignKey := range foreignKeys {
+		foreignKeys[i].Name = names[strings.ToLower(foreignKey.With.Key)]
+	}
+
+	return foreignKeys, nil
+}
+
+// joinSQLiteIdentifiers normalizes the comma-separated list of the identifiers, eg: the columns of the foreign key
+func joinSQLiteIdentifiers(list string) string {
+	var identifiers []string
+	for _, identifier := range strings.Split(list, ",") {
+		identifiers = append(identifiers, unquoteSQLiteIdentifier(strings.TrimSpace(identifier)))
+	}
+
+	return strings.Join(identifiers, ", ")
+}
+
+// Columns returns the columns of the table. The hidden columns of the virtual tables are not returned.
+// The collations and the expressions of the generated columns are parsed from the CREATE TABLE statement stored in sqlite_master table
+func (c SQLiteClient) Columns(ctx context.Context, table string) (columns []dto.ModelField, err error) {
+	createStr, err := c.selectCreateTableStr(ctx, table)
+	if err != nil {
+		return nil, err
+	}
+
+	var definitions = map[string]string{}
+	for _, definition := range splitSQLiteDefinitions(createStr) {
+		if fields := strings.Fields(definition); len(fields) > 0 {
+			definitions[unquoteSQLiteIdentifier(fields[0])] = definition
+		}
+	}
+
+	rows, err := c.executor().QueryContext(ctx, "SELECT name, type, \"notnull\", dflt_value, pk, hidden FROM pragma_table_xinfo(?) WHERE hidden <> 1 ORDER BY cid", table)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	for rows.Next() {
+		var (
+			column              dto.ModelField
+			columnType          string
+			notNull, pk, hidden int
+			defaultValue        sql.NullString
+		)
+		if err = rows.Scan(&column.Name, &columnType, &notNull, &defaultValue, &pk, &hidden); err != nil {
+			return nil, err
+		}
+
+		column.Type, column.Length, column.IsUnsigned = parseSQLiteColumnType(columnType)
+		column.IsNullable = notNull == 0 && pk == 0
+		column.IsPrimaryKey = pk > 0
+		if defaultValue.Valid {
+			column.Default = dto.RawExpression(defaultValue.String)
+		}
+
+		definition := definitions[column.Name]
+		column.AutoIncrement = sqliteAutoIncrementRegexp.MatchString(definition)
+		if matches := sqliteCollateRegexp.FindStringSubmatch(definition); matches != nil {
+			column.Collate = unquoteSQLiteIdentifier(matches[1])
+		}
+
+		//The generated columns are marked as hidden columns: 2 for the virtual column and 3 for the stored column
+		if hidden > 1 {
+			column.GeneratedType = dto.VirtualGeneratedType
+			if hidden == 3 {
+				column.GeneratedType = dto.StoredGeneratedType
 			}
 
-			result = append(result, fmt.Sprintf("DROP INDEX %s", key))
+			if loc := sqliteGeneratedRegexp.FindStringIndex(definition); loc != nil {
+				if expression := splitSQLiteDefinitions(definition[loc[1]-1:]); len(expression) > 0 {
+					column.Generated = expression[0]
+				}
+			}
 		}
+
+		columns = append(columns, column)
 	}
 
-	if len(result) > 0 {
-		queryStr += strings.Join(result, ";\n")
+	return columns, rows.Err()
+}
+
+// TableOptions returns the options of the table. The WITHOUT ROWID and STRICT options are parsed from the CREATE TABLE statement stored in sqlite_master table
+func (c SQLiteClient) TableOptions(ctx context.Context, table string) (options dto.TableOptions, err error) {
+	createStr, err := c.selectCreateTableStr(ctx, table)
+	if err != nil {
+		return options, err
 	}
 
-	return queryStr
+	if matches := sqliteTableOptionsRegexp.FindStringSubmatch(createStr); matches != nil {
+		options.Strict = sqliteStrictRegexp.MatchString(matches[1])
+		options.WithoutRowID = sqliteWithoutRowIDRegexp.MatchString(matches[1])
+	}
+
+	return options, nil
+}
+
+// selectCreateTableStr returns the CREATE TABLE statement of the table from sqlite_master table
+func (c SQLiteClient) selectCreateTableStr(ctx context.Context, table string) (createStr string, err error) {
+	err = c.executor().QueryRowContext(ctx, "SELECT sql FROM sqlite_master WHERE type = 'table' AND name = ?", table).Scan(&createStr)
+	if errors.Is(err, sql.ErrNoRows) {
+		return createStr, fmt.Errorf("The table %s does not exist ", table)
+	}
+
+	return createStr, err
+}
+
+// parseSQLiteColumnType splits the declared type of the column into the type, the length and the unsigned flag. Eg: VARCHAR(100), INTEGER unsigned
+func parseSQLiteColumnType(declared string) (columnType string, length int64, isUnsigned bool) {
+	matches := sqliteColumnTypeRegexp.FindStringSubmatch(strings.TrimSpace(declared))
+	if matches == nil {
+		return declared, 0, false
+	}
+
+	length, _ = strconv.ParseInt(matches[2], 10, 64)
+
+	return matches[1], length, matches[3] != ""
 }
 
 func (c SQLiteClient) Execute(q QueryInterface) (result dto.BaseResult, err error) {
+	return c.ExecuteContext(context.Background(), q)
+}
+
+// Page executes the select query for selected page and the COUNT(*) query for the total number of rows
+func (c SQLiteClient) Page(ctx context.Context, q QueryInterface, page int64, perPage int64) (result dto.PageResult, err error) {
+	return executePage(ctx, c, q, page, perPage)
+}
+
+// ExecuteContext executes the query using the context
+func (c SQLiteClient) ExecuteContext(ctx context.Context, q QueryInterface) (result dto.BaseResult, err error) {
+	if isEmptyUpdateQuery(q) {
+		return result, nil
+	}
+
 	var queryStr = c.ToSql(q)
 	if queryStr == "" {
 		return result, errors.New("Query string cannot be empty ")
 	}
 
+	if err = c.validateQuery(q); err != nil {
+		return result, err
+	}
+
 	var bindings []interface{}
-	for _, bind := range q.GetBindings() {
+	for _, bind := range c.prepareBindings(q) {
 		bindings = append(bindings, bind.Value)
 	}
 
 	switch q.GetQueryType() {
 	case SelectType:
-		result, err = c.executeSelect(queryStr, bindings)
+		result, err = c.executeSelect(ctx, queryStr, bindings, q.GetDestination())
 	case CreateType:
-		result, err = c.executeQuery(queryStr, bindings)
+		result, err = c.executeQuery(ctx, queryStr, bindings)
 	case AlterType:
-		result, err = c.executeQuery(queryStr, bindings)
+		if isNewSchemaShouldBeGenerated(q) {
+			result, err = c.executeTableRebuild(ctx, q)
+			break
+		}
+
+		result, err = c.executeQuery(ctx, queryStr, bindings)
 	case RenameType:
-		result, err = c.executeQuery(queryStr, bindings)
+		result, err = c.executeQuery(ctx, queryStr, bindings)
 	case DeleteType:
-		result, err = c.executeQuery(queryStr, bindings)
+		result, err = c.executeQuery(ctx, queryStr, bindings)
 	case DropType:
-		result, err = c.executeQuery(queryStr, bindings)
+		result, err = c.executeQuery(ctx, queryStr, bindings)
+	case TruncateType:
+		result, err = c.executeQuery(ctx, queryStr, bindings)
+	case CreateViewType:
+		result, err = c.executeQuery(ctx, queryStr, bindings)
+	case DropViewType:
+		result, err = c.executeQuery(ctx, queryStr, bindings)
+	case CreateTriggerType:
+		result, err = c.executeQuery(ctx, queryStr, bindings)
+	case DropTriggerType:
+		result, err = c.executeQuery(ctx, queryStr, bindings)
 	case InsertType:
-		result, err = c.executeQuery(queryStr, bindings)
+		result, err = c.executeQuery(ctx, queryStr, bindings)
 	case UpdateType:
-		result, err = c.executeQuery(queryStr, bindings)
+		result, err = c.executeQuery(ctx, queryStr, bindings)
+	case TransactionBegin, TransactionCommit, TransactionRollback:
+		result, err = c.executeQuery(ctx, queryStr, bindings)
 	}
 
 	if err != nil {
@@ -215,13 +752,15 @@ func (c SQLiteClient) Execute(q QueryInterface) (result dto.BaseResult, err erro
 	return result, nil
 }
 
-func (c SQLiteClient) executeSelect(queryStr string, bindings []interface{}) (result dto.BaseResult, err error) {
-	rows, err := c.GetClient().Query(queryStr, bindings...)
+func (c SQLiteClient) executeSelect(ctx context.Context, queryStr string, bindings []interface{}, destination dto.ModelInterface) (result dto.BaseResult, err error) {
+	rows, err := c.executor().QueryContext(ctx, queryStr, bindings...)
 	if err != nil {
 		result.SetError(err)
 		return result, err
 	}
 
+	defer rows.Close()
+
 	columns, err := rows.Columns()
 	if err != nil {
 		result.SetError(err)
@@ -257,14 +796,16 @@ func (c SQLiteClient) executeSelect(queryStr string, bindings []interface{}) (re
 			})
 		}
 
+		setResultModelDestination(model, destination)
+		model.SyncOriginal()
 		result.AddItem(model)
 	}
 
 	return result, nil
 }
 
-func (c SQLiteClient) executeQuery(queryStr string, bindings []interface{}) (result dto.BaseResult, err error) {
-	rows, err := c.GetClient().Exec(queryStr, bindings...)
+func (c SQLiteClient) executeQuery(ctx context.Context, queryStr string, bindings []interface{}) (result dto.BaseResult, err error) {
+	rows, err := c.executor().ExecContext(ctx, queryStr, bindings...)
 	if err != nil {
 		result.SetError(err)
 		return result, err
@@ -278,3 +819,536 @@ func (c SQLiteClient) executeQuery(queryStr string, bindings []interface{}) (res
 
 	return result, nil
 }
+
+// sqliteSchemaObject the index, trigger or view from sqlite_master table
+type sqliteSchemaObject struct {
+	Type string
+	Name string
+	SQL  string
+}
+
+// executeTableRebuild executes the ALTER query, which rebuilds the table, using the procedure of SQLite for the generalized table schema changes.
+// The foreign keys are disabled, the table is rebuilt in the transaction, the indexes, triggers and views are recreated from sqlite_master and the enabled foreign keys are checked before the commit
+func (c SQLiteClient) executeTableRebuild(ctx context.Context, q QueryInterface) (result dto.BaseResult, err error) {
+	defer func() {
+		if err != nil {
+			result.SetError(err)
+		}
+	}()
+
+	//The pragmas are applied per connection, so all the statements should be executed using the same connection
+	if c.conn == nil {
+		if c.conn, err = c.GetClient().Conn(ctx); err != nil {
+			return result, err
+		}
+		defer c.conn.Close()
+	}
+
+	//The foreign keys cannot be disabled inside the transaction, so the rebuild cannot be executed in the transaction of the session
+	var conn = c.conn
+	isTransaction, err := isSQLiteTransactionOpen(conn)
+	if err != nil {
+		return result, err
+	}
+
+	if isTransaction {
+		return result, errors.New("The table cannot be rebuilt in the transaction, because the foreign keys cannot be disabled inside the transaction in SQLite. Please commit the transaction before the ALTER query ")
+	}
+
+	//The columns, foreign keys and constraints of the table, which are not the part of the model, are selected before the rebuild
+	if q, err = c.prepareRebuildQuery(ctx, q); err != nil {
+		return result, err
+	}
+
+	var queryStr = toSql(c, q)
+
+	var foreignKeys bool
+	if err = conn.QueryRowContext(ctx, "PRAGMA foreign_keys").Scan(&foreignKeys); err != nil {
+		return result, err
+	}
+
+	if foreignKeys {
+		if _, err = conn.ExecContext(ctx, "PRAGMA foreign_keys = OFF"); err != nil {
+			return result, err
+		}
+		defer conn.ExecContext(context.Background(), "PRAGMA foreign_keys = ON")
+	}
+
+	tx, err := conn.BeginTx(ctx, nil)
+	if err != nil {
+		return result, err
+	}
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback()
+		}
+	}()
+
+	var table = q.GetDestination().GetTableName()
+	objects, err := selectSQLiteSchemaObjects(ctx, tx, table)
+	if err != nil {
+		return result, err
+	}
+
+	//The views are recreated to be sure they are valid for the new schema
+	for _, object := range objects {
+		if object.Type != "view" {
+			continue
+		}
+
+		if _, err = tx.ExecContext(ctx, fmt.Sprintf("DROP VIEW %s", object.Name)); err != nil {
+			return result, err
+		}
+	}
+
+	//Without the legacy mode the views and triggers of the other tables, which reference the dropped table, fail the rename of the new table
+	if _, err = tx.ExecContext(ctx, fmt.Sprintf("PRAGMA legacy_alter_table = ON;\n%s\nPRAGMA legacy_alter_table = OFF;", queryStr)); err != nil {
+		return result, err
+	}
+
+	for _, object := range objects {
+		statement, ok := prepareRecreatedSchemaObjectStr(q, object)
+		if !ok {
+			continue
+		}
+
+		if _, err = tx.ExecContext(ctx, statement); err != nil {
+			return result, fmt.Errorf("Failed to recreate the %s %s after the rebuild of the table %s: %w ", object.Type, object.Name, table, err)
+		}
+	}
+
+	//The foreign keys are checked only if they were enabled, otherwise the existing rows can violate them
+	if foreignKeys {
+		if err = checkSQLiteForeignKeys(ctx, tx, table); err != nil {
+			return result, err
+		}
+	}
+
+	return result, tx.Commit()
+}
+
+// prepareRebuildQuery adds the existing columns, foreign keys and constraints of the table to the ALTER query, which rebuilds the table, so they are not lost.
+// The dropped and the redefined ones and the ones of the dropped columns are not added. The renamed columns are replaced in them
+func (c SQLiteClient) prepareRebuildQuery(ctx context.Context, q QueryInterface) (QueryInterface, error) {
+	original, ok := q.(*Query)
+	if !ok {
+		return q, errors.New("The table can be rebuilt only for the ALTER query created by clients.Query, because the existing schema of the table should be added to the query ")
+	}
+
+	var table = q.GetDestination().GetTableName()
+	columns, err := c.Columns(ctx, table)
+	if err != nil {
+		return q, err
+	}
+
+	options, err := c.TableOptions(ctx, table)
+	if err != nil {
+		return q, err
+	}
+
+	constraints, err := c.Constraints(ctx, table)
+	if err != nil {
+		return q, err
+	}
+
+	foreignKeys, err := c.foreignKeys(ctx, table)
+	if err != nil {
+		return q, err
+	}
+
+	result := original.Clone().(*Query)
+	result.destination = prepareRebuiltModel(q.GetDestination(), columns, options)
+	result.foreignKeysAdd = nil
+	for _, foreignKey := range foreignKeys {
+		if isForeignKeyReplaced(q, foreignKey) {
+			continue
+		}
+
+		if foreignKey, ok = prepareRebuiltForeignKey(q, foreignKey); ok {
+			result.foreignKeysAdd = append(result.foreignKeysAdd, foreignKey)
+		}
+	}
+
+	result.foreignKeysAdd = append(result.foreignKeysAdd, original.foreignKeysAdd...)
+
+	result.constraintsAdd = nil
+	for _, constraint := range constraints {
+		//The primary key is generated using the model
+		if constraint.Type == dto.PrimaryKeyConstraint || isConstraintReplaced(q, constraint.Name) {
+			continue
+		}
+
+		if constraint, ok = prepareRebuiltConstraint(q, constraint); ok {
+			result.constraintsAdd = append(result.constraintsAdd, constraint)
+		}
+	}
+
+	result.constraintsAdd = append(result.constraintsAdd, original.constraintsAdd...)
+
+	return result, nil
+}
+
+// prepareRebuiltModel returns the model of the rebuilt table. The columns are taken from the existing table, so the columns, which are not declared by the model, are not dropped.
+// The declared columns keep the definitions of the model
+func prepareRebuiltModel(model dto.ModelInterface, columns []dto.ModelField, options dto.TableOptions) dto.ModelInterface {
+	var (
+		primaryKey = model.GetPrimaryKey()
+		keys       []dto.ModelField
+		fields     []interface{}
+	)
+	for _, column := range columns {
+		declared := model.GetField(column.Name)
+		if declared.Name == "" && column.Name == primaryKey.Name {
+			declared = primaryKey
+		}
+
+		if declared.Type != "" {
+			column = declared
+		}
+
+		if primaryKey.Name != "" && column.Name == primaryKey.Name {
+			column.IsPrimaryKey = true
+		}
+
+		if column.IsPrimaryKey {
+			keys = append(keys, column)
+		}
+
+		fields = append(fields, column)
+	}
+
+	//The primary key of the table, which is not declared by the model
+	if primaryKey.Name == "" && len(keys) == 1 {
+		primaryKey = keys[0]
+	}
+
+	//The options of the existing table are kept too
+	tableOptions := model.GetTableOptions()
+	tableOptions.Strict = tableOptions.Strict || options.Strict
+	tableOptions.WithoutRowID = tableOptions.WithoutRowID || options.WithoutRowID
+
+	return &dto.BaseModel{
+		TableName:  model.GetTableName(),
+		PrimaryKey: primaryKey,
+		Fields:     fields,
+		Options:    tableOptions,
+	}
+}
+
+// isSQLiteTransactionOpen checks if the transaction was started for the connection, eg: by the BEGIN query executed by the session
+func isSQLiteTransactionOpen(conn *sql.Conn) (isOpen bool, err error) {
+	err = conn.Raw(func(driverConn any) error {
+		if sqliteConn, ok := driverConn.(*sqlite3.SQLiteConn); ok {
+			isOpen = !sqliteConn.AutoCommit()
+		}
+
+		return nil
+	})
+
+	return isOpen, err
+}
+
+// isForeignKeyReplaced checks if the foreign key is dropped or redefined by the alter query. The foreign keys without the name are matched by their columns
+func isForeignKeyReplaced(q QueryInterface, foreignKey dto.ForeignKey) bool {
+	for _, foreignKeys := range [][]dto.ForeignKey{q.GetForeignKeysToDrop(), q.GetForeignKeysToAdd()} {
+		for _, replaced := range foreignKeys {
+			if foreignKey.Name != "" && strings.EqualFold(replaced.Name, foreignKey.Name) {
+				return true
+			}
+
+			if (foreignKey.Name == "" || replaced.Name == "") && replaced.With.Key != "" &&
+				strings.EqualFold(strings.ReplaceAll(replaced.With.Key, " ", ""), strings.ReplaceAll(foreignKey.With.Key, " ", "")) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// prepareRebuiltForeignKey prepares the existing foreign key for the rebuilt table. The foreign keys of the dropped columns are not added.
+// The renamed columns are replaced in the referenced columns too, if the foreign key references the same table
+func prepareRebuiltForeignKey(q QueryInterface, foreignKey dto.ForeignKey) (dto.ForeignKey, bool) {
+	var isSelfReference = strings.EqualFold(foreignKey.Target.Table, q.GetDestination().GetTableName())
+	if isDroppedColumnUsed(q, foreignKey.With.Key) || (isSelfReference && isDroppedColumnUsed(q, foreignKey.Target.Key)) {
+		return foreignKey, false
+	}
+
+	foreignKey.With.Key = replaceRenamedColumns(q, foreignKey.With.Key)
+	if isSelfReference {
+		foreignKey.Target.Key = replaceRenamedColumns(q, foreignKey.Target.Key)
+	}
+
+	return foreignKey, true
+}
+
+// isConstraintReplaced checks if the constraint is dropped or redefined by the alter query
+func isConstraintReplaced(q QueryInterface, name string) bool {
+	if name == "" {
+		return false
+	}
+
+	for _, constraints := range [][]dto.Constraint{q.GetConstraintsToDrop(), q.GetConstraintsToAdd()} {
+		for _, constraint := range constraints {
+			if strings.EqualFold(constraint.Name, name) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// prepareRebuiltConstraint prepares the existing constraint for the rebuilt table. The constraints of the dropped columns are not added
+func prepareRebuiltConstraint(q QueryInterface, constraint dto.Constraint) (dto.Constraint, bool) {
+	if constraint.Type == dto.CheckConstraint {
+		if isDroppedColumnUsed(q, constraint.Expression) {
+			return constraint, false
+		}
+
+		constraint.Expression = replaceRenamedColumns(q, constraint.Expression)
+		return constraint, true
+	}
+
+	var columns []string
+	for _, column := range constraint.Columns {
+		if isDroppedColumnUsed(q, column) {
+			return constraint, false
+		}
+
+		columns = append(columns, replaceRenamedColumns(q, column))
+	}
+
+	constraint.Columns = columns
+	return constraint, true
+}
+
+// isDroppedColumnUsed checks if the dropped columns of the alter query are used in the statement
+func isDroppedColumnUsed(q QueryInterface, statement string) bool {
+	for _, column := range q.GetColumnsToDrop() {
+		switch v := column.(type) {
+		case dto.ModelField:
+			if regexp.MustCompile(fmt.Sprintf(`(?i)\b%s\b`, regexp.QuoteMeta(v.Name))).MatchString(statement) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// replaceRenamedColumns replaces the renamed columns of the alter query in the statement
+func replaceRenamedColumns(q QueryInterface, statement string) string {
+	for _, rename := range q.GetColumnsToRename() {
+		statement = regexp.MustCompile(fmt.Sprintf(`(?i)\b%s\b`, regexp.QuoteMeta(rename.From))).ReplaceAllLiteralString(statement, rename.To)
+	}
+
+	return statement
+}
+
+// selectSQLiteSchemaObjects selects the indexes and triggers of the table and the views, which reference the table. The automatic indexes of the constraints are not selected
+func selectSQLiteSchemaObjects(ctx context.Context, tx *sql.Tx, table string) (objects []sqliteSchemaObject, err error) {
+	rows, err := tx.QueryContext(ctx, `SELECT type, name, sql FROM sqlite_master
+WHERE sql IS NOT NULL AND ((type IN ('index', 'trigger') AND tbl_name = ?) OR type = 'view')
+ORDER BY CASE type WHEN 'index' THEN 1 WHEN 'view' THEN 2 ELSE 3 END`, table)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var tableRegexp = regexp.MustCompile(fmt.Sprintf(`(?i)\b%s\b`, regexp.QuoteMeta(table)))
+	for rows.Next() {
+		var object sqliteSchemaObject
+		if err = rows.Scan(&object.Type, &object.Name, &object.SQL); err != nil {
+			return nil, err
+		}
+
+		if object.Type == "view" && !tableRegexp.MatchString(object.SQL) {
+			continue
+		}
+
+		objects = append(objects, object)
+	}
+
+	return objects, rows.Err()
+}
+
+// prepareRecreatedSchemaObjectStr prepares the statement of the index, trigger or view, which should be recreated after the table rebuild.
+// The dropped and the redefined indexes and the indexes of the dropped columns are not recreated. The renamed columns are replaced in the columns of the indexes
+func prepareRecreatedSchemaObjectStr(q QueryInterface, object sqliteSchemaObject) (string, bool) {
+	if object.Type != "index" {
+		return object.SQL, true
+	}
+
+	for _, index := range append(q.GetIndexesToDrop(), q.GetIndexesToAdd()...) {
+		if strings.EqualFold(index.Name, object.Name) || (index.Name == "" && strings.EqualFold(index.Key, object.Name)) {
+			return "", false
+		}
+	}
+
+	var position = strings.Index(object.SQL, "(")
+	if position == -1 {
+		return object.SQL, true
+	}
+
+	var columnsStr = object.SQL[position:]
+	if isDroppedColumnUsed(q, columnsStr) {
+		return "", false
+	}
+
+	return object.SQL[:position] + replaceRenamedColumns(q, columnsStr), true
+}
+
+// checkSQLiteForeignKeys checks if the foreign key constraints of the database are not violated after the table rebuild
+func checkSQLiteForeignKeys(ctx context.Context, tx *sql.Tx, table string) error {
+	rows, err := tx.QueryContext(ctx, "PRAGMA foreign_key_check")
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	if rows.Next() {
+		var (
+			child  string
+			rowID  sql.NullInt64
+			parent string
+			fkID   int64
+		)
+		if err = rows.Scan(&child, &rowID, &parent, &fkID); err != nil {
+			return err
+		}
+
+		return fmt.Errorf("The foreign key constraint of the table %s, which references the table %s, is violated after the rebuild of the table %s ", child, parent, table)
+	}
+
+	return rows.Err()
+}
+
+// Views returns the views of the database. The definitions of the views are parsed from the CREATE VIEW statements stored in sqlite_master table
+func (c SQLiteClient) Views(ctx context.Context) (views []dto.View, err error) {
+	rows, err := c.executor().QueryContext(ctx, "SELECT name, sql FROM sqlite_master WHERE type = 'view' ORDER BY name")
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	for rows.Next() {
+		var view dto.View
+		if err = rows.Scan(&view.Name, &view.Definition); err != nil {
+			return nil, err
+		}
+
+		if matches := sqliteViewRegexp.FindStringSubmatch(view.Definition); matches != nil {
+			view.Definition = matches[1]
+		}
+
+		views = append(views, view)
+	}
+
+	return views, rows.Err()
+}
+
+// Triggers returns the triggers of the table. The triggers are parsed from the CREATE TRIGGER statements stored in sqlite_master table
+func (c SQLiteClient) Triggers(ctx context.Context, table string) (triggers []dto.Trigger, err error) {
+	rows, err := c.executor().QueryContext(ctx, "SELECT name, sql FROM sqlite_master WHERE type = 'trigger' AND tbl_name = ? ORDER BY name", table)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	for rows.Next() {
+		var name, createStr string
+		if err = rows.Scan(&name, &createStr); err != nil {
+			return nil, err
+		}
+
+		//The BEFORE timing is used by default
+		trigger := dto.Trigger{Name: name, Table: table, Timing: dto.BeforeTriggerTiming}
+		if matches := sqliteTriggerRegexp.FindStringSubmatch(createStr); matches != nil {
+			if matches[1] != "" {
+				trigger.Timing = strings.ToUpper(strings.Join(strings.Fields(matches[1]), " "))
+			}
+
+			trigger.Event = strings.ToUpper(matches[2])
+			trigger.Body = parseTriggerBody(matches[3])
+		}
+
+		triggers = append(triggers, trigger)
+	}
+
+	return triggers, rows.Err()
+}
+
+var (
+	sqliteTriggerRegexp          = regexp.MustCompile(`(?is)^CREATE\s+(?:TEMP\s+|TEMPORARY\s+)?TRIGGER\s+.+?\s+(BEFORE|AFTER|INSTEAD\s+OF)?\s*\b(INSERT|UPDATE|DELETE)\b.*?\s+ON\s+\S+\s+.*?(BEGIN\s+.*END)$`)
+	sqliteViewRegexp             = regexp.MustCompile(`(?is)^CREATE\s+(?:TEMP\s+|TEMPORARY\s+)?VIEW\s+(?:IF\s+NOT\s+EXISTS\s+)?.+?\s+AS\s+(.*)$`)
+	sqliteConstraintRegexp       = regexp.MustCompile(`(?is)^(?:CONSTRAINT\s+(\S+)\s+)?(PRIMARY\s+KEY|UNIQUE|CHECK)\s*\((.*)\)[^)]*$`)
+	sqliteInlinePrimaryKeyRegexp = regexp.MustCompile(`(?is)\bCONSTRAINT\s+(\S+)\s+PRIMARY\s+KEY\b`)
+	sqliteForeignKeyRegexp       = regexp.MustCompile(`(?is)^CONSTRAINT\s+(\S+)\s+FOREIGN\s+KEY\s*\(([^)]*)\)`)
+	sqliteAutoIncrementRegexp    = regexp.MustCompile(`(?i)\bautoincrement\b`)
+	sqliteCollateRegexp          = regexp.MustCompile(`(?i)\bCOLLATE\s+(\S+)`)
+	sqliteGeneratedRegexp        = regexp.MustCompile(`(?i)\b(?:GENERATED\s+ALWAYS\s+)?AS\s*\(`)
+	sqliteColumnTypeRegexp       = regexp.MustCompile(`(?i)^(.*?)(?:\s*\(\s*(\d+)\s*\))?(\s+unsigned)?$`)
+	sqliteTableOptionsRegexp     = regexp.MustCompile(`\)([^)]*)$`)
+	sqliteStrictRegexp           = regexp.MustCompile(`(?i)\bSTRICT\b`)
+	sqliteWithoutRowIDRegexp     = regexp.MustCompile(`(?i)\bWITHOUT\s+ROWID\b`)
+)
+
+// splitSQLiteDefinitions splits the content of the first parentheses of the statement by the top-level commas. Eg: the columns and the constraints of the CREATE TABLE statement
+func splitSQLiteDefinitions(statement string) (definitions []string) {
+	var (
+		depth   int
+		start   = -1
+		quote   rune
+		current strings.Builder
+	)
+	for i, char := range statement {
+		if quote != 0 {
+			if char == quote {
+				quote = 0
+			}
+
+			if start >= 0 {
+				current.WriteRune(char)
+			}
+
+			continue
+		}
+
+		switch char {
+		case '\'', '"', '`':
+			quote = char
+		case '[':
+			quote = ']'
+		case '(':
+			depth++
+			if depth == 1 {
+				start = i
+				continue
+			}
+		case ')':
+			depth--
+			if depth == 0 {
+				return append(definitions, strings.TrimSpace(current.String()))
+			}
+		case ',':
+			if depth == 1 {
+				definitions = append(definitions, strings.TrimSpace(current.String()))
+				current.Reset()
+				continue
+			}
+		}
+
+		if start >= 0 {
+			current.WriteRune(char)
+		}
+	}
+
+	return definitions
+}
+
+func unquoteSQLiteIdentifier(identifier string) string {
+	return strings.Trim(identifier, "`\"[]'")
+}
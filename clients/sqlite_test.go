@@ -1,8 +1,10 @@
 package clients
 
 import (
+	"context"
 	"fmt"
 	"os"
+	"strings"
 	"testing"
 
 	"github.com/sharovik/orm/dto"
@@ -143,6 +145,23 @@ var (
 					To:   11,
 				})),
 		},
+		{
+			Expected: `SELECT * FROM test_table_name LIMIT 10 OFFSET 20`,
+			Original: SQLiteClient{}.ToSql(new(Query).Select([]interface{}{}).
+				From(&m).
+				Limit(query.Limit{
+					Offset: 20,
+					Count:  10,
+				})),
+		},
+		{
+			Expected: `SELECT * FROM test_table_name LIMIT 10`,
+			Original: SQLiteClient{}.ToSql(new(Query).Select([]interface{}{}).
+				From(&m).
+				Limit(query.Limit{
+					Count: 10,
+				})),
+		},
 		{
 			Expected: `SELECT * FROM test_table_name WHERE test_table_name2.relation_id = 2 AND col1 = "test" AND ? = ? LIMIT 11`,
 			Original: SQLiteClient{}.ToSql(new(Query).Select([]interface{}{}).
@@ -272,6 +291,22 @@ var (
 					Type:     query.WhereAndType,
 				})),
 		},
+		{
+			Expected: "SELECT relation_id, COUNT(id) AS total FROM test_table_name WHERE col1 = ? GROUP BY relation_id HAVING COUNT(id) > ?",
+			Original: SQLiteClient{}.ToSql(new(Query).Select([]interface{}{"relation_id", "COUNT(id) AS total"}).
+				From(&m).
+				Where(query.Where{
+					First:    "col1",
+					Operator: "=",
+					Second:   query.Bind{Field: "col1", Value: 2},
+				}).
+				GroupBy("relation_id").
+				Having(query.Where{
+					First:    "COUNT(id)",
+					Operator: ">",
+					Second:   query.Bind{Field: "total", Value: 1},
+				})),
+		},
 	}
 )
 
@@ -281,6 +316,442 @@ func TestSQLiteClient_SelectToSql(t *testing.T) {
 	}
 }
 
+func TestQuery_HavingBindingsOrder(t *testing.T) {
+	q := new(Query).Select([]interface{}{"relation_id"}).
+		From(&m).
+		GroupBy("relation_id").
+		Having(query.Where{
+			First:    "COUNT(id)",
+			Operator: ">",
+			Second:   query.Bind{Field: "total", Value: 1},
+		}).
+		Where(query.Where{
+			First:    "col1",
+			Operator: "=",
+			Second:   query.Bind{Field: "col1", Value: 2},
+		})
+
+	assert.Equal(t, "SELECT relation_id FROM test_table_name WHERE col1 = ? GROUP BY relation_id HAVING COUNT(id) > ?", SQLiteClient{}.ToSql(q))
+	assert.Equal(t, []query.Bind{
+		{Field: "col1", Value: 2},
+		{Field: "total", Value: 1},
+	}, q.GetBindings())
+}
+
+func TestQuery_SubQueries(t *testing.T) {
+	subQuery := new(Query).Select([]interface{}{"relation_id"}).
+		From(&model2).
+		Where(query.Where{
+			First:    "col1",
+			Operator: "=",
+			Second:   query.Bind{Field: "col1", Value: 1},
+		})
+
+	q := new(Query).Select([]interface{}{"id"}).
+		From(&m).
+		Where(query.Where{
+			First:    "relation_id",
+			Operator: "IN",
+			Second:   subQuery,
+		}).
+		Where(query.Where{
+			First:    "col2",
+			Operator: "=",
+			Second:   query.Bind{Field: "col2", Value: 2},
+		})
+	assert.Equal(t, "SELECT id FROM test_table_name WHERE relation_id IN (SELECT relation_id FROM test_table_name2 WHERE col1 = ?) AND col2 = ?", SQLiteClient{}.ToSql(q))
+	assert.Equal(t, []query.Bind{{Field: "col1", Value: 1}, {Field: "col2", Value: 2}}, q.GetBindings())
+
+	q = new(Query).Select([]interface{}{"id"}).
+		From(&m).
+		Where(query.Where{
+			Operator: "EXISTS",
+			Second:   subQuery,
+		})
+	assert.Equal(t, "SELECT id FROM test_table_name WHERE EXISTS (SELECT relation_id FROM test_table_name2 WHERE col1 = ?)", MySQLClient{}.ToSql(q))
+	assert.Equal(t, []query.Bind{{Field: "col1", Value: 1}}, q.GetBindings())
+
+	q = new(Query).Select([]interface{}{"t.relation_id"}).
+		From(SubQuery{Query: subQuery, Alias: "t"}).
+		Join(query.Join{
+			Target:    query.Reference{Table: "j", Key: "relation_id"},
+			With:      query.Reference{Table: "t", Key: "relation_id"},
+			Condition: "=",
+			Type:      query.InnerJoinType,
+			Query: new(Query).Select([]interface{}{"relation_id"}).
+				From(&m).
+				Where(query.Where{
+					First:    "col3",
+					Operator: "=",
+					Second:   query.Bind{Field: "col3", Value: "test"},
+				}),
+		}).
+		Where(query.Where{
+			First:    "t.relation_id",
+			Operator: ">",
+			Second:   query.Bind{Field: "relation_id", Value: 3},
+		})
+	assert.Equal(t, "SELECT t.relation_id FROM (SELECT relation_id FROM test_table_name2 WHERE col1 = ?) AS t INNER JOIN (SELECT relation_id FROM test_table_name WHERE col3 = ?) AS j ON (j.relation_id = t.relation_id) WHERE t.relation_id > ?", SQLiteClient{}.ToSql(q))
+	assert.Equal(t, []query.Bind{
+		{Field: "col1", Value: 1},
+		{Field: "col3", Value: "test"},
+		{Field: "relation_id", Value: 3},
+	}, q.GetBindings())
+
+	model := initTestModel("test_table_name")
+	q = new(Query).Insert(&model).Values(subQuery)
+	assert.Equal(t, "INSERT INTO test_table_name (relation_id, col1, col2, col3) SELECT relation_id FROM test_table_name2 WHERE col1 = ?", SQLiteClient{}.ToSql(q))
+	assert.Equal(t, []query.Bind{{Field: "col1", Value: 1}}, q.GetBindings())
+}
+
+func TestQuery_WhereIn(t *testing.T) {
+	q := new(Query).Select([]interface{}{"id"}).
+		From(&m).
+		Where(query.In("id", []int{1, 2, 3})).
+		Where(query.NotIn("relation_id", []int64{4}))
+	assert.Equal(t, "SELECT id FROM test_table_name WHERE id IN (?, ?, ?) AND relation_id NOT IN (?)", SQLiteClient{}.ToSql(q))
+	assert.Equal(t, []query.Bind{
+		{Field: "id", Value: 1},
+		{Field: "id", Value: 2},
+		{Field: "id", Value: 3},
+		{Field: "relation_id", Value: int64(4)},
+	}, q.GetBindings())
+
+	q = new(Query).Select([]interface{}{"id"}).
+		From(&m).
+		Where(query.In("id", []int{}))
+	assert.Equal(t, "SELECT id FROM test_table_name WHERE 1 = 0", SQLiteClient{}.ToSql(q))
+	assert.Empty(t, q.GetBindings())
+
+	defaultChunkSize := query.InChunkSize
+	query.InChunkSize = 2
+	defer func() {
+		query.InChunkSize = defaultChunkSize
+	}()
+
+	q = new(Query).Select([]interface{}{"id"}).
+		From(&m).
+		Where(query.In("id", []int{1, 2, 3}))
+	assert.Equal(t, "SELECT id FROM test_table_name WHERE (id IN (?, ?) OR id IN (?))", MySQLClient{}.ToSql(q))
+	assert.Len(t, q.GetBindings(), 3)
+}
+
+func TestQuery_ConditionsBuilder(t *testing.T) {
+	q := new(Query).Select([]interface{}{"id"}).
+		From(&m).
+		Where(query.And(
+			query.Eq("a", 1),
+			query.Or(
+				query.Like("name", "x%"),
+				query.IsNull("deleted_at"),
+			),
+		)).
+		Where(query.Or(
+			query.Between("created", 10, 20),
+			query.Not(query.And(
+				query.NotEq("b", 2),
+				query.Lt("c", 3),
+				query.Gte("d", 4),
+			)),
+			query.NotLike("name", "y%"),
+			query.IsNotNull("updated_at"),
+		))
+
+	assert.Equal(t, "SELECT id FROM test_table_name WHERE (a = ? AND (name LIKE ? OR deleted_at IS NULL)) AND (created BETWEEN ? AND ? OR NOT (b <> ? AND c < ? AND d >= ?) OR name NOT LIKE ? OR updated_at IS NOT NULL)", SQLiteClient{}.ToSql(q))
+	assert.Equal(t, []query.Bind{
+		{Field: "a", Value: 1},
+		{Field: "name", Value: "x%"},
+		{Field: "created", Value: 10},
+		{Field: "created", Value: 20},
+		{Field: "b", Value: 2},
+		{Field: "c", Value: 3},
+		{Field: "d", Value: 4},
+		{Field: "name", Value: "y%"},
+	}, q.GetBindings())
+
+	q = new(Query).Select([]interface{}{"id"}).
+		From(&m).
+		Where(query.Gt("a", 1)).
+		Where(query.Or(query.Eq("b", 2), query.In("c", []int{3, 4})))
+	assert.Equal(t, "SELECT id FROM test_table_name WHERE a > ? AND (b = ? OR c IN (?, ?))", MySQLClient{}.ToSql(q))
+	assert.Len(t, q.GetBindings(), 4)
+}
+
+func TestQuery_Compounds(t *testing.T) {
+	first := new(Query).Select([]interface{}{"id"}).From(&m).Where(query.Eq("col1", 1))
+	second := new(Query).Select([]interface{}{"id"}).From(&model2).Where(query.Eq("col2", 2))
+	q := new(Query).Select([]interface{}{"id"}).
+		Distinct().
+		From(&m).
+		Where(query.Gt("id", 10)).
+		Union(first).
+		UnionAll(second).
+		OrderBy("id", query.OrderDirectionDesc).
+		Limit(query.Limit{To: 5})
+
+	assert.Equal(t, "SELECT DISTINCT id FROM test_table_name WHERE id > ? UNION SELECT id FROM test_table_name WHERE col1 = ? UNION ALL SELECT id FROM test_table_name2 WHERE col2 = ? ORDER BY id DESC LIMIT 5", SQLiteClient{}.ToSql(q))
+	assert.Equal(t, []query.Bind{
+		{Field: "id", Value: 10},
+		{Field: "col1", Value: 1},
+		{Field: "col2", Value: 2},
+	}, q.GetBindings())
+
+	q = new(Query).Select([]interface{}{"id"}).
+		From(&m).
+		Intersect(new(Query).Select([]interface{}{"id"}).From(&model2)).
+		Except(new(Query).Select([]interface{}{"id"}).From(&model2).OrderBy("id", query.OrderDirectionAsc).Limit(query.Limit{To: 1}))
+	assert.Equal(t, "SELECT id FROM test_table_name INTERSECT SELECT id FROM test_table_name2 EXCEPT SELECT * FROM (SELECT id FROM test_table_name2 ORDER BY id ASC LIMIT 1) AS compound_1", MySQLClient{}.ToSql(q))
+	assert.True(t, hasCompound(q, IntersectCompound))
+	assert.False(t, hasCompound(q, UnionCompound))
+}
+
+func TestQuery_With(t *testing.T) {
+	active := new(Query).Select([]interface{}{"id"}).From(&model2).Where(query.Eq("col1", 1))
+
+	q := new(Query).With("active", active).
+		Select([]interface{}{"id"}).
+		From(&m).
+		Where(query.Where{
+			First:    "relation_id",
+			Operator: query.InOperator,
+			Second:   new(Query).Select([]interface{}{"id"}).From("active"),
+		}).
+		Where(query.Eq("col2", 2))
+	assert.Equal(t, "WITH active AS (SELECT id FROM test_table_name2 WHERE col1 = ?) SELECT id FROM test_table_name WHERE relation_id IN (SELECT id FROM active) AND col2 = ?", SQLiteClient{}.ToSql(q))
+	assert.Equal(t, []query.Bind{{Field: "col1", Value: 1}, {Field: "col2", Value: 2}}, q.GetBindings())
+
+	model := initTestModel("test_table_name")
+	q = new(Query).With("active", active).Update(&model).Where(query.Where{
+		First:    "relation_id",
+		Operator: query.InOperator,
+		Second:   new(Query).Select([]interface{}{"id"}).From("active"),
+	})
+	assert.Equal(t, "WITH active AS (SELECT id FROM test_table_name2 WHERE col1 = ?) UPDATE test_table_name SET relation_id = ?, col1 = ?, col2 = ?, col3 = ? WHERE relation_id IN (SELECT id FROM active)", MySQLClient{}.ToSql(q))
+	assert.Len(t, q.GetBindings(), 5)
+	assert.Equal(t, query.Bind{Field: "col1", Value: 1}, q.GetBindings()[0])
+
+	q = new(Query).With("active", active).Delete().From(&model).Where(query.Where{
+		First:    "relation_id",
+		Operator: query.InOperator,
+		Second:   new(Query).Select([]interface{}{"id"}).From("active"),
+	})
+	assert.Equal(t, "WITH active AS (SELECT id FROM test_table_name2 WHERE col1 = ?) DELETE FROM test_table_name WHERE relation_id IN (SELECT id FROM active)", SQLiteClient{}.ToSql(q))
+}
+
+func TestSQLiteClient_ExecuteRecursiveWith(t *testing.T) {
+	removeDatabase()
+	initDatabase()
+	defer removeDatabase()
+
+	sqliteClient, err := SQLiteClient{}.Connect(DatabaseConfig{
+		Host: testSQLiteDatabasePath,
+	})
+	assert.NoError(t, err)
+
+	model := dto.BaseModel{
+		TableName: "categories",
+		Fields: []interface{}{
+			dto.ModelField{
+				Name:       "parent_id",
+				Type:       dto.IntegerColumnType,
+				IsNullable: true,
+			},
+		},
+	}
+	model.SetPrimaryKey(dto.ModelField{
+		Name:          "id",
+		Type:          dto.IntegerColumnType,
+		AutoIncrement: true,
+	})
+
+	_, err = sqliteClient.Execute(new(Query).Create(&model))
+	assert.NoError(t, err)
+
+	for _, parentID := range []interface{}{nil, 1, 2, nil} {
+		model.AddModelField(dto.ModelField{Name: "parent_id", Value: parentID})
+		_, err = sqliteClient.Execute(new(Query).Insert(&model))
+		assert.NoError(t, err)
+	}
+
+	tree := new(Query).Select([]interface{}{"id"}).
+		From(&model).
+		Where(query.Eq("id", 1)).
+		UnionAll(new(Query).Select([]interface{}{"categories.id"}).
+			From(&model).
+			Join(query.Join{
+				Target:    query.Reference{Table: "tree", Key: "id"},
+				With:      query.Reference{Table: "categories", Key: "parent_id"},
+				Condition: "=",
+				Type:      query.InnerJoinType,
+			}))
+
+	res, err := sqliteClient.Execute(new(Query).
+		WithRecursive("tree(id)", tree).
+		Select([]interface{}{"id"}).
+		From("tree").
+		OrderBy("id", query.OrderDirectionAsc))
+	assert.NoError(t, err)
+	assert.Len(t, res.Items(), 3)
+	for i, item := range res.Items() {
+		assert.Equal(t, i+1, item.GetField("id").Value)
+	}
+}
+
+func TestQuery_WindowFunctions(t *testing.T) {
+	byRelation := query.Window{
+		PartitionBy: []string{"relation_id"},
+		OrderBy: []query.OrderByColumn{
+			{Column: "id", Direction: query.OrderDirectionAsc},
+		},
+	}
+
+	q := new(Query).Select([]interface{}{
+		"id",
+		query.RowNumber().Over(byRelation).As("row_num"),
+		query.Rank().Over(query.Window{OrderBy: []query.OrderByColumn{{Column: "col1", Direction: query.OrderDirectionDesc}}}),
+		query.Lag("col1", 1).Over(query.Window{Name: "w"}).As("previous"),
+		query.Lead("col1", 1).Over(query.Window{Name: "w"}).As("next"),
+		query.Sum("col2").Over(query.Window{
+			Name:  "w",
+			Frame: "ROWS BETWEEN UNBOUNDED PRECEDING AND CURRENT ROW",
+		}).As("running_total"),
+	}).
+		From(&m).
+		Window("w", byRelation).
+		OrderBy("id", query.OrderDirectionAsc)
+
+	assert.Equal(t, "SELECT id, ROW_NUMBER() OVER (PARTITION BY relation_id ORDER BY id ASC) AS row_num, RANK() OVER (ORDER BY col1 DESC), LAG(col1, 1) OVER w AS previous, LEAD(col1, 1) OVER w AS next, SUM(col2) OVER (w ROWS BETWEEN UNBOUNDED PRECEDING AND CURRENT ROW) AS running_total FROM test_table_name WINDOW w AS (PARTITION BY relation_id ORDER BY id ASC) ORDER BY id ASC", SQLiteClient{}.ToSql(q))
+	assert.True(t, hasWindowFunctions(q))
+	assert.False(t, hasWindowFunctions(new(Query).Select([]interface{}{"id"}).From(&m)))
+}
+
+func TestSQLiteClient_ExecuteWindowFunctions(t *testing.T) {
+	removeDatabase()
+	initDatabase()
+	defer removeDatabase()
+
+	sqliteClient, err := SQLiteClient{}.Connect(DatabaseConfig{
+		Host: testSQLiteDatabasePath,
+	})
+	assert.NoError(t, err)
+
+	model := initTestModel("testing")
+	_, err = sqliteClient.Execute(new(Query).Create(&model))
+	assert.NoError(t, err)
+
+	for i := 1; i <= 3; i++ {
+		model.AddModelField(dto.ModelField{Name: "col2", Value: i})
+		_, err = sqliteClient.Execute(new(Query).Insert(&model))
+		assert.NoError(t, err)
+	}
+
+	res, err := sqliteClient.Execute(new(Query).Select([]interface{}{
+		"id",
+		query.Sum("col2").Over(query.Window{Name: "w"}).As("running_total"),
+	}).
+		From(&model).
+		Window("w", query.Window{OrderBy: []query.OrderByColumn{{Column: "id", Direction: query.OrderDirectionAsc}}}).
+		OrderBy("id", query.OrderDirectionAsc))
+	assert.NoError(t, err)
+	assert.Len(t, res.Items(), 3)
+	for i, expected := range []int{1, 3, 6} {
+		assert.Equal(t, expected, res.Items()[i].GetField("running_total").Value)
+	}
+}
+
+func TestQuery_Joins(t *testing.T) {
+	q := new(Query).Select([]interface{}{"test_table_name.id", "parent.id"}).
+		From(&m).
+		Join(query.Join{
+			Target:    query.Reference{Table: "test_table_name", Key: "id"},
+			With:      query.Reference{Table: "test_table_name", Key: "relation_id"},
+			Condition: "=",
+			Type:      query.LeftJoinType,
+			Alias:     "parent",
+			On:        []query.Where{query.Eq("parent.col1", 1), query.Or(query.IsNull("parent.col2"), query.Gt("parent.col2", 2))},
+		}).
+		Join(query.Join{
+			Target: query.Reference{Table: "test_table_name2"},
+			Type:   query.InnerJoinType,
+			On: []query.Where{{
+				First:    "test_table_name2.relation_id",
+				Operator: "=",
+				Second:   "test_table_name.id",
+			}},
+		}).
+		Join(query.Join{
+			Target: query.Reference{Table: "test_table_name3"},
+			Type:   query.InnerJoinType,
+			Using:  []string{"relation_id", "col1"},
+		}).
+		Join(query.Join{
+			Target: query.Reference{Table: "test_table_name4"},
+			Type:   query.CrossJoinType,
+		}).
+		Where(query.Eq("test_table_name.col3", "test"))
+
+	assert.Equal(t, "SELECT test_table_name.id, parent.id FROM test_table_name LEFT JOIN test_table_name AS parent ON (parent.id = test_table_name.relation_id AND (parent.col1 = ? AND (parent.col2 IS NULL OR parent.col2 > ?))) INNER JOIN test_table_name2 ON (test_table_name2.relation_id = test_table_name.id) INNER JOIN test_table_name3 USING (relation_id, col1) CROSS JOIN test_table_name4 WHERE test_table_name.col3 = ?", SQLiteClient{}.ToSql(q))
+	assert.Equal(t, []query.Bind{
+		{Field: "parent.col1", Value: 1},
+		{Field: "parent.col2", Value: 2},
+		{Field: "test_table_name.col3", Value: "test"},
+	}, q.GetBindings())
+}
+
+func TestQuery_FullJoin(t *testing.T) {
+	q := new(Query).Select([]interface{}{"test_table_name.id", "test_table_name2.id"}).
+		From(&m).
+		Join(query.Join{
+			Target:    query.Reference{Table: "test_table_name2", Key: "relation_id"},
+			With:      query.Reference{Table: "test_table_name", Key: "id"},
+			Condition: "=",
+			Type:      query.FullJoinType,
+			On:        []query.Where{query.Eq("test_table_name2.col1", 1)},
+		}).
+		Where(query.Eq("test_table_name.col2", 2)).
+		Union(new(Query).Select([]interface{}{"id", "relation_id"}).From(&model2).Where(query.Eq("col3", 3))).
+		OrderBy("id", query.OrderDirectionAsc)
+
+	assert.Equal(t, "SELECT test_table_name.id, test_table_name2.id FROM test_table_name FULL OUTER JOIN test_table_name2 ON (test_table_name2.relation_id = test_table_name.id AND test_table_name2.col1 = ?) WHERE test_table_name.col2 = ? UNION SELECT id, relation_id FROM test_table_name2 WHERE col3 = ? ORDER BY id ASC", SQLiteClient{}.ToSql(q))
+	assert.Equal(t, q.GetBindings(), SQLiteClient{}.prepareBindings(q))
+
+	assert.Equal(t, "SELECT test_table_name.id, test_table_name2.id FROM test_table_name LEFT JOIN test_table_name2 ON (test_table_name2.relation_id = test_table_name.id AND test_table_name2.col1 = ?) WHERE test_table_name.col2 = ? UNION ALL SELECT test_table_name.id, test_table_name2.id FROM test_table_name RIGHT JOIN test_table_name2 ON (test_table_name2.relation_id = test_table_name.id AND test_table_name2.col1 = ?) WHERE test_table_name.col2 = ? AND test_table_name.id IS NULL UNION SELECT id, relation_id FROM test_table_name2 WHERE col3 = ? ORDER BY id ASC", MySQLClient{}.ToSql(q))
+	assert.Equal(t, []query.Bind{
+		{Field: "test_table_name2.col1", Value: 1},
+		{Field: "test_table_name.col2", Value: 2},
+		{Field: "test_table_name2.col1", Value: 1},
+		{Field: "test_table_name.col2", Value: 2},
+		{Field: "col3", Value: 3},
+	}, MySQLClient{}.prepareBindings(q))
+
+	assert.Error(t, MySQLClient{}.validateQuery(q.GroupBy("test_table_name.id")))
+}
+
+func TestQuery_MixedLimit(t *testing.T) {
+	for _, limit := range []query.Limit{{From: 10, Count: 5}, {To: 5, Offset: 10}} {
+		q := new(Query).Select([]interface{}{"id"}).From(&m).Limit(limit)
+		assert.Error(t, SQLiteClient{}.validateQuery(q))
+		assert.Error(t, MySQLClient{}.validateQuery(q))
+	}
+
+	for _, limit := range []query.Limit{{From: 10, To: 5}, {Offset: 10, Count: 5}} {
+		q := new(Query).Select([]interface{}{"id"}).From(&m).Limit(limit)
+		assert.NoError(t, SQLiteClient{}.validateQuery(q))
+		assert.NoError(t, MySQLClient{}.validateQuery(q))
+	}
+}
+
+func TestSQLiteClient_LockIsIgnored(t *testing.T) {
+	q := new(Query).Select([]interface{}{"id"}).
+		From(&m).
+		Where(query.Eq("col1", 1)).
+		Limit(query.Limit{Count: 10}).
+		ForUpdate().
+		SkipLocked()
+	assert.Equal(t, "SELECT id FROM test_table_name WHERE col1 = ? LIMIT 10", SQLiteClient{}.ToSql(q))
+	assert.Equal(t, query.Lock{Strength: query.ForUpdateLock, Option: query.SkipLockedLockOption}, q.GetLock())
+}
+
 func TestSQLiteClient_InsertToSql(t *testing.T) {
 	var (
 		model     = initTestModel("test_table_name")
@@ -311,6 +782,18 @@ func TestSQLiteClient_DropToSql(t *testing.T) {
 				Expected: "DROP TABLE test_table_name",
 				Original: SQLiteClient{}.ToSql(new(Query).Drop(&model)),
 			},
+			{
+				Expected: "DROP TABLE IF EXISTS test_table_name",
+				Original: SQLiteClient{}.ToSql(new(Query).Drop(&model).IfExists()),
+			},
+			{
+				Expected: "DELETE FROM test_table_name;\nDELETE FROM sqlite_sequence WHERE name = 'test_table_name'",
+				Original: SQLiteClient{}.ToSql(new(Query).Truncate(&model)),
+			},
+			{
+				Expected: "DELETE FROM test_table_name",
+				Original: SQLiteClient{}.ToSql(new(Query).Truncate(&dto.BaseModel{TableName: "test_table_name"})),
+			},
 		}
 	)
 
@@ -319,6 +802,46 @@ func TestSQLiteClient_DropToSql(t *testing.T) {
 	}
 }
 
+func TestSQLiteClient_ExecuteTruncateAndDropIfExists(t *testing.T) {
+	removeDatabase()
+	initDatabase()
+	defer removeDatabase()
+
+	sqliteClient, err := SQLiteClient{}.Connect(DatabaseConfig{
+		Host: testSQLiteDatabasePath,
+	})
+	assert.NoError(t, err)
+
+	model := initTestModel("testing")
+	_, err = sqliteClient.Execute(new(Query).Create(&model))
+	assert.NoError(t, err)
+
+	for i := 0; i < 2; i++ {
+		_, err = sqliteClient.Execute(new(Query).Insert(&model))
+		assert.NoError(t, err)
+	}
+
+	_, err = sqliteClient.Execute(new(Query).Truncate(&model))
+	assert.NoError(t, err)
+
+	res, err := sqliteClient.Execute(new(Query).Select([]interface{}{"id"}).From(&model))
+	assert.NoError(t, err)
+	assert.Empty(t, res.Items())
+
+	//The sequence of the primary key is reset
+	res, err = sqliteClient.Execute(new(Query).Insert(&model))
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), res.LastInsertID())
+
+	for i := 0; i < 2; i++ {
+		_, err = sqliteClient.Execute(new(Query).Drop(&model).IfExists())
+		assert.NoError(t, err)
+	}
+
+	_, err = sqliteClient.Execute(new(Query).Drop(&model))
+	assert.Error(t, err)
+}
+
 func TestSQLiteClient_RenameToSql(t *testing.T) {
 	var (
 		model     = initTestModel("test_table_name")
@@ -368,7 +891,7 @@ func TestSQLiteClient_AlterToSql(t *testing.T) {
 				})),
 			},
 			{
-				Expected: "CREATE INDEX my_brand_new_index on test_table_name (request_id)",
+				Expected: "CREATE INDEX my_brand_new_index ON test_table_name (request_id)",
 				Original: SQLiteClient{}.ToSql(new(Query).Alter(&model).AddIndex(dto.Index{
 					Name:   "my_brand_new_index",
 					Target: "test_table_name",
@@ -377,7 +900,7 @@ func TestSQLiteClient_AlterToSql(t *testing.T) {
 				})),
 			},
 			{
-				Expected: "CREATE UNIQUE INDEX my_brand_unique_new_index on test_table_name (request_id);\nCREATE INDEX my_brand_non_unique_new_index on test_table_name (name)",
+				Expected: "CREATE UNIQUE INDEX my_brand_unique_new_index ON test_table_name (request_id);\nCREATE INDEX my_brand_non_unique_new_index ON test_table_name (name)",
 				Original: SQLiteClient{}.ToSql(new(Query).Alter(&model).AddIndex(dto.Index{
 					Name:   "my_brand_unique_new_index",
 					Target: "test_table_name",
@@ -390,7 +913,7 @@ func TestSQLiteClient_AlterToSql(t *testing.T) {
 				})),
 			},
 			{
-				Expected: "CREATE INDEX my_brand_non_unique_new_index on test_table_name (name);\nDROP INDEX my_brand_unique_new_index",
+				Expected: "CREATE INDEX my_brand_non_unique_new_index ON test_table_name (name);\nDROP INDEX my_brand_unique_new_index",
 				Original: SQLiteClient{}.ToSql(new(Query).Alter(&model).DropIndex(dto.Index{
 					Name: "my_brand_unique_new_index",
 				}).AddIndex(dto.Index{
@@ -400,21 +923,21 @@ func TestSQLiteClient_AlterToSql(t *testing.T) {
 				})),
 			},
 			{
-				Expected: "CREATE TABLE temp_test_table_name (id INTEGER CONSTRAINT temp_test_table_name_pk primary key autoincrement, relation_id INTEGER NOT NULL, col1 INTEGER NOT NULL, col2 INTEGER NOT NULL);\nINSERT INTO temp_test_table_name (relation_id, col1, col2) SELECT relation_id, col1, col2 FROM test_table_name;\nALTER TABLE `test_table_name` RENAME TO `old_test_table_name`;\nALTER TABLE `temp_test_table_name` RENAME TO `test_table_name`;\nDROP TABLE old_test_table_name;",
+				Expected: "CREATE TABLE temp_test_table_name (id INTEGER CONSTRAINT test_table_name_pk primary key autoincrement, relation_id INTEGER NOT NULL, col1 INTEGER NOT NULL, col2 INTEGER NOT NULL);\nINSERT INTO temp_test_table_name (id, relation_id, col1, col2) SELECT id, relation_id, col1, col2 FROM test_table_name;\nDROP TABLE test_table_name;\nALTER TABLE `temp_test_table_name` RENAME TO `test_table_name`;",
 				Original: SQLiteClient{}.ToSql(new(Query).Alter(&model).
 					DropColumn(dto.ModelField{
 						Name: "col3",
 					})),
 			},
 			{
-				Expected: "CREATE TABLE temp_test_table_name (id INTEGER CONSTRAINT temp_test_table_name_pk primary key autoincrement, relation_id INTEGER NOT NULL, col1 INTEGER NOT NULL, col2 INTEGER NOT NULL, col3 VARCHAR NOT NULL);\nINSERT INTO temp_test_table_name (relation_id, col1, col2, col3) SELECT relation_id, col1, col2, col3 FROM test_table_name;\nALTER TABLE `test_table_name` RENAME TO `old_test_table_name`;\nALTER TABLE `temp_test_table_name` RENAME TO `test_table_name`;\nDROP TABLE old_test_table_name;",
+				Expected: "CREATE TABLE temp_test_table_name (id INTEGER CONSTRAINT test_table_name_pk primary key autoincrement, relation_id INTEGER NOT NULL, col1 INTEGER NOT NULL, col2 INTEGER NOT NULL, col3 VARCHAR NOT NULL);\nINSERT INTO temp_test_table_name (id, relation_id, col1, col2, col3) SELECT id, relation_id, col1, col2, col3 FROM test_table_name;\nDROP TABLE test_table_name;\nALTER TABLE `temp_test_table_name` RENAME TO `test_table_name`;",
 				Original: SQLiteClient{}.ToSql(new(Query).Alter(&model).
 					DropForeignKey(dto.ForeignKey{
 						Name: "test_foreign_key",
 					})),
 			},
 			{
-				Expected: "CREATE TABLE temp_test_table_name (id INTEGER CONSTRAINT temp_test_table_name_pk primary key autoincrement, relation_id INTEGER NOT NULL, col1 INTEGER NOT NULL, col2 INTEGER NOT NULL, col3 VARCHAR NOT NULL,\nCONSTRAINT fk_test\nFOREIGN KEY (relation_id)\n REFERENCES test_table_name2 (id)\nON DELETE NO ACTION\nON UPDATE NO ACTION);\nINSERT INTO temp_test_table_name (relation_id, col1, col2, col3) SELECT relation_id, col1, col2, col3 FROM test_table_name;\nALTER TABLE `test_table_name` RENAME TO `old_test_table_name`;\nALTER TABLE `temp_test_table_name` RENAME TO `test_table_name`;\nDROP TABLE old_test_table_name;",
+				Expected: "CREATE TABLE temp_test_table_name (id INTEGER CONSTRAINT test_table_name_pk primary key autoincrement, relation_id INTEGER NOT NULL, col1 INTEGER NOT NULL, col2 INTEGER NOT NULL, col3 VARCHAR NOT NULL,\nCONSTRAINT fk_test\nFOREIGN KEY (relation_id)\n REFERENCES test_table_name2 (id)\nON DELETE NO ACTION\nON UPDATE NO ACTION);\nINSERT INTO temp_test_table_name (id, relation_id, col1, col2, col3) SELECT id, relation_id, col1, col2, col3 FROM test_table_name;\nDROP TABLE test_table_name;\nALTER TABLE `temp_test_table_name` RENAME TO `test_table_name`;",
 				Original: SQLiteClient{}.ToSql(new(Query).Alter(&model).
 					AddForeignKey(dto.ForeignKey{
 						Name: "fk_test",
@@ -438,6 +961,322 @@ func TestSQLiteClient_AlterToSql(t *testing.T) {
 	}
 }
 
+func TestSQLiteClient_AlterColumnsToSql(t *testing.T) {
+	model := initTestModel("test_table_name")
+
+	assert.Equal(t, `CREATE TABLE temp_test_table_name (id INTEGER CONSTRAINT test_table_name_pk primary key autoincrement, relation_id INTEGER NOT NULL, col4 INTEGER NOT NULL, col2 VARCHAR NULL, col3 VARCHAR DEFAULT "test" NOT NULL);
+INSERT INTO temp_test_table_name (id, relation_id, col4, col2, col3) SELECT id, relation_id, col1, col2, col3 FROM test_table_name;
+DROP TABLE test_table_name;
+ALTER TABLE `+"`temp_test_table_name` RENAME TO `test_table_name`"+`;`, SQLiteClient{}.ToSql(new(Query).Alter(&model).
+		RenameColumn("col1", "col4").
+		ModifyColumn(dto.ModelField{Name: "col2", Type: dto.VarcharColumnType, IsNullable: true}).
+		ModifyColumn(dto.ModelField{Name: "col3", Type: dto.VarcharColumnType, Default: "test"})))
+}
+
+func TestSQLiteClient_ExecuteAlterColumns(t *testing.T) {
+	removeDatabase()
+	initDatabase()
+	defer removeDatabase()
+
+	sqliteClient, err := SQLiteClient{}.Connect(DatabaseConfig{
+		Host: testSQLiteDatabasePath,
+	})
+	assert.NoError(t, err)
+
+	model := initTestModel("testing")
+	_, err = sqliteClient.Execute(new(Query).Create(&model))
+	assert.NoError(t, err)
+
+	_, err = sqliteClient.Execute(new(Query).Insert(&model))
+	assert.NoError(t, err)
+
+	_, err = sqliteClient.Execute(new(Query).Alter(&model).
+		RenameColumn("col1", "col4").
+		RenameColumn("col3", "title").
+		ModifyColumn(dto.ModelField{Name: "title", Type: dto.VarcharColumnType, IsNullable: true}))
+	assert.NoError(t, err)
+
+	res, err := sqliteClient.Execute(new(Query).Select([]interface{}{"relation_id", "col4", "col2", "title"}).From("testing"))
+	assert.NoError(t, err)
+	assert.Len(t, res.Items(), 1)
+	assert.Equal(t, 1, res.Items()[0].GetField("relation_id").Value)
+	assert.Equal(t, 2, res.Items()[0].GetField("col4").Value)
+	assert.Equal(t, "Test", res.Items()[0].GetField("title").Value)
+
+	//The modified column allows NULL values now
+	_, err = sqliteClient.Execute(new(Query).UpdateTable("testing").Set("title", nil))
+	assert.NoError(t, err)
+}
+
+func TestSQLiteClient_RebuildToSql(t *testing.T) {
+	model := initTestModel("test_table_name")
+
+	assert.Equal(t, `CREATE TABLE temp_test_table_name (id INTEGER CONSTRAINT test_table_name_pk primary key autoincrement, relation_id INTEGER NOT NULL, col1 INTEGER NOT NULL, col3 VARCHAR NOT NULL, col5 INTEGER NULL);
+INSERT INTO temp_test_table_name (id, relation_id, col1, col3) SELECT id, relation_id, col1, col3 FROM test_table_name;
+DROP TABLE test_table_name;
+ALTER TABLE `+"`temp_test_table_name` RENAME TO `test_table_name`"+`;
+CREATE INDEX col1_index ON test_table_name (col1);`, SQLiteClient{}.ToSql(new(Query).Alter(&model).
+		DropColumn(dto.ModelField{Name: "col2"}).
+		AddColumn(dto.ModelField{Name: "col5", Type: dto.IntegerColumnType, IsNullable: true}).
+		AddIndex(dto.Index{Name: "col1_index", Key: "col1"})))
+}
+
+func TestSQLiteClient_ExecuteRebuild(t *testing.T) {
+	removeDatabase()
+	initDatabase()
+	defer removeDatabase()
+
+	sqliteClient, err := SQLiteClient{}.Connect(DatabaseConfig{
+		Host: testSQLiteDatabasePath,
+	})
+	assert.NoError(t, err)
+
+	//The foreign keys are enabled for the connection, so only one connection should be used
+	sqliteClient.GetClient().SetMaxOpenConns(1)
+	_, err = sqliteClient.GetClient().Exec("PRAGMA foreign_keys = ON")
+	assert.NoError(t, err)
+
+	model := initTestModel("testing")
+	_, err = sqliteClient.Execute(new(Query).Create(&model).
+		AddIndex(dto.Index{Name: "testing_col1_index", Key: "col1"}).
+		AddIndex(dto.Index{Name: "testing_col2_index", Key: "col2"}))
+	assert.NoError(t, err)
+
+	for _, statement := range []string{
+		"CREATE TABLE children (id INTEGER PRIMARY KEY, testing_id INTEGER REFERENCES testing (id))",
+		"CREATE VIEW testing_view AS SELECT id, col3 FROM testing",
+		"CREATE TRIGGER testing_trigger AFTER UPDATE ON testing BEGIN UPDATE children SET testing_id = NEW.id WHERE testing_id = OLD.id; END",
+		"CREATE TRIGGER children_trigger AFTER INSERT ON children BEGIN UPDATE testing SET col1 = col1 + 1 WHERE id = NEW.testing_id; END",
+	} {
+		_, err = sqliteClient.GetClient().Exec(statement)
+		assert.NoError(t, err)
+	}
+
+	for i := 0; i < 2; i++ {
+		_, err = sqliteClient.Execute(new(Query).Insert(&model))
+		assert.NoError(t, err)
+	}
+
+	_, err = sqliteClient.Execute(new(Query).Delete().From(&model).Where(query.Eq("id", 1)))
+	assert.NoError(t, err)
+
+	_, err = sqliteClient.GetClient().Exec("INSERT INTO children (testing_id) VALUES (2)")
+	assert.NoError(t, err)
+
+	_, err = sqliteClient.Execute(new(Query).Alter(&model).
+		DropColumn(dto.ModelField{Name: "col2"}).
+		RenameColumn("col1", "col4").
+		AddIndex(dto.Index{Name: "testing_col3_index", Key: "col3"}))
+	assert.NoError(t, err)
+
+	//The primary keys are preserved, so the rows of the other tables reference the same rows
+	res, err := sqliteClient.Execute(new(Query).Select([]interface{}{"id", "col4", "col3"}).From("testing"))
+	assert.NoError(t, err)
+	assert.Len(t, res.Items(), 1)
+	assert.Equal(t, 2, res.Items()[0].GetField("id").Value)
+	assert.Equal(t, 3, res.Items()[0].GetField("col4").Value)
+
+	var objects []string
+	rows, err := sqliteClient.GetClient().Query("SELECT name || ': ' || sql FROM sqlite_master WHERE type IN ('index', 'trigger', 'view') AND sql IS NOT NULL ORDER BY name")
+	assert.NoError(t, err)
+	for rows.Next() {
+		var object string
+		assert.NoError(t, rows.Scan(&object))
+		objects = append(objects, object)
+	}
+	assert.NoError(t, rows.Close())
+	assert.Equal(t, []string{
+		"children_trigger: CREATE TRIGGER children_trigger AFTER INSERT ON children BEGIN UPDATE testing SET col1 = col1 + 1 WHERE id = NEW.testing_id; END",
+		"testing_col1_index: CREATE INDEX testing_col1_index ON testing (col4)",
+		"testing_col3_index: CREATE INDEX testing_col3_index ON testing (col3)",
+		"testing_trigger: CREATE TRIGGER testing_trigger AFTER UPDATE ON testing BEGIN UPDATE children SET testing_id = NEW.id WHERE testing_id = OLD.id; END",
+		"testing_view: CREATE VIEW testing_view AS SELECT id, col3 FROM testing",
+	}, objects)
+
+	res, err = sqliteClient.Execute(new(Query).Select([]interface{}{"col3"}).From("testing_view"))
+	assert.NoError(t, err)
+	assert.Len(t, res.Items(), 1)
+
+	//The rebuild, which violates the foreign keys, is rolled back
+	_, err = sqliteClient.Execute(new(Query).UpdateTable("testing").Set("relation_id", 10))
+	assert.NoError(t, err)
+
+	altered := dto.BaseModel{
+		TableName:  "testing",
+		PrimaryKey: model.GetPrimaryKey(),
+		Fields: []interface{}{
+			dto.ModelField{Name: "relation_id", Type: dto.IntegerColumnType},
+			dto.ModelField{Name: "col4", Type: dto.IntegerColumnType},
+			dto.ModelField{Name: "col3", Type: dto.VarcharColumnType},
+		},
+	}
+	_, err = sqliteClient.Execute(new(Query).Alter(&altered).AddForeignKey(dto.ForeignKey{
+		Name:   "fk_relation",
+		Target: query.Reference{Table: "children", Key: "id"},
+		With:   query.Reference{Table: "testing", Key: "relation_id"},
+	}))
+	assert.ErrorContains(t, err, "foreign key constraint")
+
+	res, err = sqliteClient.Execute(new(Query).Select([]interface{}{"id", "col4", "col3"}).From("testing"))
+	assert.NoError(t, err)
+	assert.Len(t, res.Items(), 1)
+
+	var foreignKeys bool
+	assert.NoError(t, sqliteClient.GetClient().QueryRow("PRAGMA foreign_keys").Scan(&foreignKeys))
+	assert.True(t, foreignKeys)
+
+	//The existing foreign keys and constraints of the table, which are not the part of the model, are preserved
+	kids := dto.BaseModel{
+		TableName:  "kids",
+		PrimaryKey: dto.ModelField{Name: "id", Type: dto.IntegerColumnType, AutoIncrement: true},
+		Fields: []interface{}{
+			dto.ModelField{Name: "parent_id", Type: dto.IntegerColumnType},
+			dto.ModelField{Name: "child_id", Type: dto.IntegerColumnType, IsNullable: true},
+			dto.ModelField{Name: "name", Type: dto.VarcharColumnType},
+			dto.ModelField{Name: "age", Type: dto.IntegerColumnType},
+			dto.ModelField{Name: "note", Type: dto.VarcharColumnType, IsNullable: true},
+		},
+	}
+	_, err = sqliteClient.Execute(new(Query).Create(&kids).
+		AddConstraint(dto.Constraint{Name: "kids_name_uq", Type: dto.UniqueConstraint, Columns: []string{"parent_id", "name"}}).
+		AddConstraint(dto.Constraint{Name: "kids_age_ck", Type: dto.CheckConstraint, Expression: "age >= 0"}).
+		AddForeignKey(dto.ForeignKey{
+			Name:     "fk_kids_parent",
+			Target:   query.Reference{Table: "testing", Key: "id"},
+			With:     query.Reference{Table: "kids", Key: "parent_id"},
+			OnDelete: dto.CascadeAction,
+		}).
+		AddForeignKey(dto.ForeignKey{
+			Name:   "fk_kids_child",
+			Target: query.Reference{Table: "children", Key: "id"},
+			With:   query.Reference{Table: "kids", Key: "child_id"},
+		}))
+	assert.NoError(t, err)
+
+	_, err = sqliteClient.Execute(new(Query).Alter(&kids).DropColumn(dto.ModelField{Name: "note"}))
+	assert.NoError(t, err)
+
+	constraints, err := sqliteClient.Constraints(context.Background(), "kids")
+	assert.NoError(t, err)
+	assert.Equal(t, []dto.Constraint{
+		{Name: "kids_pk", Type: dto.PrimaryKeyConstraint, Columns: []string{"id"}},
+		{Name: "kids_name_uq", Type: dto.UniqueConstraint, Columns: []string{"parent_id", "name"}},
+		{Name: "kids_age_ck", Type: dto.CheckConstraint, Expression: "age >= 0"},
+	}, constraints)
+
+	kidsForeignKeys, err := sqliteClient.(SQLiteClient).foreignKeys(context.Background(), "kids")
+	assert.NoError(t, err)
+	assert.Equal(t, []dto.ForeignKey{
+		{
+			Name:     "fk_kids_parent",
+			Target:   query.Reference{Table: "testing", Key: "id"},
+			With:     query.Reference{Table: "kids", Key: "parent_id"},
+			OnDelete: dto.CascadeAction,
+			OnUpdate: dto.NoActionAction,
+		},
+		{
+			Name:     "fk_kids_child",
+			Target:   query.Reference{Table: "children", Key: "id"},
+			With:     query.Reference{Table: "kids", Key: "child_id"},
+			OnDelete: dto.NoActionAction,
+			OnUpdate: dto.NoActionAction,
+		},
+	}, kidsForeignKeys)
+
+	kids.RemoveModelField("note")
+	for _, values := range [][]interface{}{{10, "John", 1}, {2, "John", -1}} {
+		kids.UpdateFieldValue("parent_id", values[0])
+		kids.UpdateFieldValue("name", values[1])
+		kids.UpdateFieldValue("age", values[2])
+		_, err = sqliteClient.Execute(new(Query).Insert(&kids))
+		assert.Error(t, err)
+	}
+
+	kids.UpdateFieldValue("age", 1)
+	_, err = sqliteClient.Execute(new(Query).Insert(&kids))
+	assert.NoError(t, err)
+
+	_, err = sqliteClient.Execute(new(Query).Insert(&kids))
+	assert.Error(t, err)
+
+	//Only the dropped foreign key is removed
+	_, err = sqliteClient.Execute(new(Query).Alter(&kids).DropForeignKey(dto.ForeignKey{Name: "fk_kids_child"}))
+	assert.NoError(t, err)
+
+	kidsForeignKeys, err = sqliteClient.(SQLiteClient).foreignKeys(context.Background(), "kids")
+	assert.NoError(t, err)
+	assert.Len(t, kidsForeignKeys, 1)
+	assert.Equal(t, "fk_kids_parent", kidsForeignKeys[0].Name)
+}
+
+func TestSQLiteClient_ExecuteRebuildExistingSchema(t *testing.T) {
+	removeDatabase()
+	initDatabase()
+	defer removeDatabase()
+
+	sqliteClient, err := SQLiteClient{}.Connect(DatabaseConfig{
+		Host: testSQLiteDatabasePath,
+	})
+	assert.NoError(t, err)
+	defer sqliteClient.Disconnect()
+
+	model := initTestModel("testing")
+	_, err = sqliteClient.Execute(new(Query).Create(&model))
+	assert.NoError(t, err)
+
+	//The column, which is not declared by the model
+	_, err = sqliteClient.GetClient().Exec("ALTER TABLE testing ADD COLUMN extra VARCHAR DEFAULT 'none'")
+	assert.NoError(t, err)
+	_, err = sqliteClient.GetClient().Exec("INSERT INTO testing (relation_id, col1, col2, col3, extra) VALUES (1, 2, 3, 'Test', 'kept')")
+	assert.NoError(t, err)
+
+	//The connected client generates the same query, which is executed
+	q := new(Query).Alter(&model).DropColumn(dto.ModelField{Name: "col2"})
+	assert.Equal(t, `CREATE TABLE temp_testing (id INTEGER CONSTRAINT testing_pk primary key autoincrement, relation_id INTEGER NOT NULL, col1 INTEGER NOT NULL, col3 VARCHAR NOT NULL, extra VARCHAR DEFAULT 'none' NULL);
+INSERT INTO temp_testing (id, relation_id, col1, col3, extra) SELECT id, relation_id, col1, col3, extra FROM testing;
+DROP TABLE testing;
+ALTER TABLE `+"`temp_testing` RENAME TO `testing`;", sqliteClient.ToSql(q))
+	assert.NotContains(t, SQLiteClient{}.ToSql(q), "extra")
+
+	_, err = sqliteClient.Execute(q)
+	assert.NoError(t, err)
+
+	res, err := sqliteClient.Execute(new(Query).Select([]interface{}{"id", "extra"}).From(&model))
+	assert.NoError(t, err)
+	assert.Len(t, res.Items(), 1)
+	assert.Equal(t, "kept", res.Items()[0].GetField("extra").Value)
+
+	//The existing schema cannot be added to the custom query implementation
+	_, err = sqliteClient.Execute(testWrappedQuery{Query: new(Query).Alter(&model).DropColumn(dto.ModelField{Name: "col3"}).(*Query)})
+	assert.Error(t, err)
+
+	//The foreign keys cannot be disabled in the transaction, so the rebuild is rejected
+	session, err := sqliteClient.Session(context.Background())
+	assert.NoError(t, err)
+	defer session.Disconnect()
+
+	_, err = session.Execute(new(Query).BeginTransaction())
+	assert.NoError(t, err)
+
+	_, err = session.Execute(new(Query).Alter(&model).DropColumn(dto.ModelField{Name: "col3"}))
+	assert.ErrorContains(t, err, "The table cannot be rebuilt in the transaction")
+
+	_, err = session.Execute(new(Query).RollbackTransaction())
+	assert.NoError(t, err)
+
+	_, err = session.Execute(new(Query).Alter(&model).DropColumn(dto.ModelField{Name: "col3"}))
+	assert.NoError(t, err)
+
+	columns, err := session.(SQLiteClient).Columns(context.Background(), "testing")
+	assert.NoError(t, err)
+
+	var names []string
+	for _, column := range columns {
+		names = append(names, column.Name)
+	}
+	assert.Equal(t, []string{"id", "relation_id", "col1", "extra"}, names)
+}
+
 func TestSQLiteClient_CreateToSql(t *testing.T) {
 	var model = dto.BaseModel{
 		TableName: "test_table_name",
@@ -481,7 +1320,7 @@ func TestSQLiteClient_CreateToSql(t *testing.T) {
 		}
 		testCases = [...]expectation{
 			{
-				Expected: "CREATE TABLE test_table_name (id INTEGER CONSTRAINT test_table_name_pk primary key autoincrement, relation_id INTEGER NOT NULL, relation_id2 INTEGER NOT NULL, title VARCHAR DEFAULT \"test\" NOT NULL, description VARCHAR NULL,\nCONSTRAINT event_id\nFOREIGN KEY (event_id)\n REFERENCES some_other_table (id)\nON DELETE CASCADE\nON UPDATE NO ACTION,\nCONSTRAINT scenario_id\nFOREIGN KEY (scenario_id)\n REFERENCES some_other_table2 (id)\nON DELETE CASCADE\nON UPDATE NO ACTION); CREATE INDEX user_id_index \nON test_table_name (user);\nCREATE INDEX channel_index \nON test_table_name (channel);\nCREATE INDEX created_index \nON test_table_name (created);",
+				Expected: "CREATE TABLE test_table_name (id INTEGER CONSTRAINT test_table_name_pk primary key autoincrement, relation_id INTEGER NOT NULL, relation_id2 INTEGER NOT NULL, title VARCHAR DEFAULT \"test\" NOT NULL, description VARCHAR NULL,\nCONSTRAINT event_id\nFOREIGN KEY (event_id)\n REFERENCES some_other_table (id)\nON DELETE CASCADE\nON UPDATE NO ACTION,\nCONSTRAINT scenario_id\nFOREIGN KEY (scenario_id)\n REFERENCES some_other_table2 (id)\nON DELETE CASCADE\nON UPDATE NO ACTION);\nCREATE INDEX user_id_index ON test_table_name (user);\nCREATE INDEX channel_index ON test_table_name (channel);\nCREATE INDEX created_index ON test_table_name (created);",
 				Original: SQLiteClient{}.ToSql(new(Query).
 					Create(&model).
 					AddIndex(dto.Index{
@@ -580,7 +1419,7 @@ func TestSQLiteClient_CreateToSql(t *testing.T) {
 				})),
 			},
 			{
-				Expected: "CREATE TABLE test_table_name (id INTEGER CONSTRAINT test_table_name_pk primary key autoincrement, relation_id INTEGER NOT NULL, relation_id2 INTEGER NOT NULL, title VARCHAR DEFAULT \"test\" NOT NULL, description VARCHAR NULL); CREATE INDEX the_index_name \nON test_table_name (relation_id);",
+				Expected: "CREATE TABLE test_table_name (id INTEGER CONSTRAINT test_table_name_pk primary key autoincrement, relation_id INTEGER NOT NULL, relation_id2 INTEGER NOT NULL, title VARCHAR DEFAULT \"test\" NOT NULL, description VARCHAR NULL);\nCREATE INDEX the_index_name ON test_table_name (relation_id);",
 				Original: SQLiteClient{}.ToSql(new(Query).Create(&model).
 					AddIndex(dto.Index{
 						Name:   "the_index_name",
@@ -590,7 +1429,7 @@ func TestSQLiteClient_CreateToSql(t *testing.T) {
 					})),
 			},
 			{
-				Expected: "CREATE TABLE test_table_name (id INTEGER CONSTRAINT test_table_name_pk primary key autoincrement, relation_id INTEGER NOT NULL, relation_id2 INTEGER NOT NULL, title VARCHAR DEFAULT \"test\" NOT NULL, description VARCHAR NULL); CREATE UNIQUE INDEX the_index_name \nON test_table_name (relation_id);",
+				Expected: "CREATE TABLE test_table_name (id INTEGER CONSTRAINT test_table_name_pk primary key autoincrement, relation_id INTEGER NOT NULL, relation_id2 INTEGER NOT NULL, title VARCHAR DEFAULT \"test\" NOT NULL, description VARCHAR NULL);\nCREATE UNIQUE INDEX the_index_name ON test_table_name (relation_id);",
 				Original: SQLiteClient{}.ToSql(new(Query).Create(&model).
 					AddIndex(dto.Index{
 						Name:   "the_index_name",
@@ -600,7 +1439,7 @@ func TestSQLiteClient_CreateToSql(t *testing.T) {
 					})),
 			},
 			{
-				Expected: "CREATE TABLE IF NOT EXISTS test_table_name (id INTEGER CONSTRAINT test_table_name_pk primary key autoincrement, relation_id INTEGER NOT NULL, relation_id2 INTEGER NOT NULL, title VARCHAR DEFAULT \"test\" NOT NULL, description VARCHAR NULL); CREATE UNIQUE INDEX the_index_name \nON test_table_name (relation_id);",
+				Expected: "CREATE TABLE IF NOT EXISTS test_table_name (id INTEGER CONSTRAINT test_table_name_pk primary key autoincrement, relation_id INTEGER NOT NULL, relation_id2 INTEGER NOT NULL, title VARCHAR DEFAULT \"test\" NOT NULL, description VARCHAR NULL);\nCREATE UNIQUE INDEX IF NOT EXISTS the_index_name ON test_table_name (relation_id);",
 				Original: SQLiteClient{}.ToSql(new(Query).Create(&model).
 					IfNotExists().
 					AddIndex(dto.Index{
@@ -613,9 +1452,541 @@ func TestSQLiteClient_CreateToSql(t *testing.T) {
 		}
 	)
 
-	for _, testCase := range testCases {
-		assert.Equal(t, testCase.Expected, testCase.Original)
+	for _, testCase := range testCases {
+		assert.Equal(t, testCase.Expected, testCase.Original)
+	}
+}
+
+func TestSQLiteClient_ConstraintsToSql(t *testing.T) {
+	model := initCompositeTestModel()
+	var (
+		unique = dto.Constraint{Name: "user_groups_email_uq", Type: dto.UniqueConstraint, Columns: []string{"group_id", "email"}}
+		check  = dto.Constraint{Name: "user_groups_position_ck", Type: dto.CheckConstraint, Expression: "position >= 0"}
+	)
+
+	assert.Equal(t, "CREATE TABLE user_groups (user_id INTEGER NOT NULL, group_id INTEGER NOT NULL, email VARCHAR NOT NULL, position INTEGER NOT NULL,\nCONSTRAINT user_groups_pk PRIMARY KEY (user_id, group_id),\nCONSTRAINT user_groups_email_uq UNIQUE (group_id, email),\nCONSTRAINT user_groups_position_ck CHECK (position >= 0));",
+		SQLiteClient{}.ToSql(new(Query).Create(&model).AddConstraint(unique).AddConstraint(check)))
+
+	//SQLite cannot add the constraints using ALTER TABLE statement, so the table is rebuilt
+	assert.Equal(t, `CREATE TABLE temp_user_groups (user_id INTEGER NOT NULL, group_id INTEGER NOT NULL, email VARCHAR NOT NULL, position INTEGER NOT NULL,
+CONSTRAINT user_groups_pk PRIMARY KEY (user_id, group_id),
+CONSTRAINT user_groups_position_ck CHECK (position >= 0));
+INSERT INTO temp_user_groups (user_id, group_id, email, position) SELECT user_id, group_id, email, position FROM user_groups;
+DROP TABLE user_groups;
+ALTER TABLE `+"`temp_user_groups` RENAME TO `user_groups`"+`;`, SQLiteClient{}.ToSql(new(Query).Alter(&model).AddConstraint(check)))
+}
+
+func TestSQLiteClient_Constraints(t *testing.T) {
+	removeDatabase()
+	initDatabase()
+	defer removeDatabase()
+
+	sqliteClient, err := SQLiteClient{}.Connect(DatabaseConfig{
+		Host: testSQLiteDatabasePath,
+	})
+	assert.NoError(t, err)
+
+	model := initCompositeTestModel()
+	_, err = sqliteClient.Execute(new(Query).Create(&model).
+		AddConstraint(dto.Constraint{Name: "user_groups_email_uq", Type: dto.UniqueConstraint, Columns: []string{"group_id", "email"}}).
+		AddConstraint(dto.Constraint{Name: "user_groups_position_ck", Type: dto.CheckConstraint, Expression: "position >= 0 AND (position < 100)"}))
+	assert.NoError(t, err)
+
+	constraints, err := sqliteClient.Constraints(context.Background(), "user_groups")
+	assert.NoError(t, err)
+	assert.Equal(t, []dto.Constraint{
+		{Name: "user_groups_pk", Type: dto.PrimaryKeyConstraint, Columns: []string{"user_id", "group_id"}},
+		{Name: "user_groups_email_uq", Type: dto.UniqueConstraint, Columns: []string{"group_id", "email"}},
+		{Name: "user_groups_position_ck", Type: dto.CheckConstraint, Expression: "position >= 0 AND (position < 100)"},
+	}, constraints)
+
+	model.AddModelField(dto.ModelField{Name: "position", Value: -1})
+	_, err = sqliteClient.Execute(new(Query).Insert(&model))
+	assert.Error(t, err)
+
+	//Only the dropped constraint is removed by the table rebuild, the other constraints are preserved
+	_, err = sqliteClient.Execute(new(Query).Alter(&model).DropConstraint(dto.Constraint{Name: "user_groups_position_ck", Type: dto.CheckConstraint}))
+	assert.NoError(t, err)
+
+	constraints, err = sqliteClient.Constraints(context.Background(), "user_groups")
+	assert.NoError(t, err)
+	assert.Equal(t, []dto.Constraint{
+		{Name: "user_groups_pk", Type: dto.PrimaryKeyConstraint, Columns: []string{"user_id", "group_id"}},
+		{Name: "user_groups_email_uq", Type: dto.UniqueConstraint, Columns: []string{"group_id", "email"}},
+	}, constraints)
+
+	row := dto.BaseModel{TableName: "user_groups", Fields: []interface{}{
+		dto.ModelField{Name: "user_id", Value: 1},
+		dto.ModelField{Name: "group_id", Value: 1},
+		dto.ModelField{Name: "email", Value: "test@example.com"},
+		dto.ModelField{Name: "position", Value: -1},
+	}}
+	_, err = sqliteClient.Execute(new(Query).Insert(&row))
+	assert.NoError(t, err)
+
+	//The added constraint does not remove the existing constraints, the renamed columns are replaced in them
+	_, err = sqliteClient.Execute(new(Query).Alter(&model).
+		RenameColumn("email", "login").
+		AddConstraint(dto.Constraint{Name: "user_groups_user_ck", Type: dto.CheckConstraint, Expression: "user_id > 0"}))
+	assert.NoError(t, err)
+
+	constraints, err = sqliteClient.Constraints(context.Background(), "user_groups")
+	assert.NoError(t, err)
+	assert.Equal(t, []dto.Constraint{
+		{Name: "user_groups_pk", Type: dto.PrimaryKeyConstraint, Columns: []string{"user_id", "group_id"}},
+		{Name: "user_groups_email_uq", Type: dto.UniqueConstraint, Columns: []string{"group_id", "login"}},
+		{Name: "user_groups_user_ck", Type: dto.CheckConstraint, Expression: "user_id > 0"},
+	}, constraints)
+
+	testModel := initTestModel("testing")
+	_, err = sqliteClient.Execute(new(Query).Create(&testModel))
+	assert.NoError(t, err)
+
+	constraints, err = sqliteClient.Constraints(context.Background(), "testing")
+	assert.NoError(t, err)
+	assert.Equal(t, []dto.Constraint{
+		{Name: "testing_pk", Type: dto.PrimaryKeyConstraint, Columns: []string{"id"}},
+	}, constraints)
+
+	_, err = sqliteClient.Constraints(context.Background(), "unknown")
+	assert.Error(t, err)
+}
+
+func TestSQLiteClient_IndexesToSql(t *testing.T) {
+	model := initTestModel("test_table_name")
+	var (
+		partial = dto.Index{
+			Name:    "col3_col1_index",
+			Columns: []dto.IndexColumn{{Name: "col3", Length: 10}, {Name: "col1", Direction: "desc"}},
+			Where:   "col2 > 0",
+		}
+		expression = dto.Index{
+			Name:    "lower_col3_index",
+			Columns: []dto.IndexColumn{{Expression: "LOWER(col3)"}},
+			Unique:  true,
+		}
+	)
+
+	assert.Equal(t, "CREATE TABLE test_table_name (id INTEGER CONSTRAINT test_table_name_pk primary key autoincrement, relation_id INTEGER NOT NULL, col1 INTEGER NOT NULL, col2 INTEGER NOT NULL, col3 VARCHAR NOT NULL);\nCREATE INDEX col3_col1_index ON test_table_name (col3, col1 DESC) WHERE col2 > 0;\nCREATE UNIQUE INDEX lower_col3_index ON test_table_name ((LOWER(col3)));",
+		SQLiteClient{}.ToSql(new(Query).Create(&model).AddIndex(partial).AddIndex(expression)))
+
+	assert.Equal(t, "CREATE INDEX IF NOT EXISTS col3_col1_index ON test_table_name (col3, col1 DESC) WHERE col2 > 0;\nDROP INDEX IF EXISTS old_index",
+		SQLiteClient{}.ToSql(new(Query).Alter(&model).
+			AddIndex(dto.Index{Name: partial.Name, Columns: partial.Columns, Where: partial.Where, IfNotExists: true}).
+			DropIndex(dto.Index{Name: "old_index", IfExists: true})))
+}
+
+func TestSQLiteClient_ExecuteIndexes(t *testing.T) {
+	removeDatabase()
+	initDatabase()
+	defer removeDatabase()
+
+	sqliteClient, err := SQLiteClient{}.Connect(DatabaseConfig{
+		Host: testSQLiteDatabasePath,
+	})
+	assert.NoError(t, err)
+
+	model := initTestModel("testing")
+	_, err = sqliteClient.Execute(new(Query).Create(&model).AddIndex(dto.Index{
+		Name:    "testing_lower_col3_index",
+		Columns: []dto.IndexColumn{{Expression: "LOWER(col3)"}},
+		Unique:  true,
+		Where:   "col1 > 0",
+	}))
+	assert.NoError(t, err)
+
+	_, err = sqliteClient.Execute(new(Query).Insert(&model))
+	assert.NoError(t, err)
+
+	//The unique expression index does not allow the same value in the different case
+	model.AddModelField(dto.ModelField{Name: "col3", Value: "TEST"})
+	_, err = sqliteClient.Execute(new(Query).Insert(&model))
+	assert.Error(t, err)
+
+	//The rows which do not match the predicate of the partial index are not indexed
+	model.AddModelField(dto.ModelField{Name: "col1", Value: 0})
+	_, err = sqliteClient.Execute(new(Query).Insert(&model))
+	assert.NoError(t, err)
+
+	for i := 0; i < 2; i++ {
+		_, err = sqliteClient.Execute(new(Query).Alter(&model).AddIndex(dto.Index{
+			Name:        "testing_col1_index",
+			Columns:     []dto.IndexColumn{{Name: "col1", Direction: query.OrderDirectionDesc}},
+			IfNotExists: true,
+		}))
+		assert.NoError(t, err)
+	}
+
+	for i := 0; i < 2; i++ {
+		_, err = sqliteClient.Execute(new(Query).Alter(&model).DropIndex(dto.Index{Name: "testing_col1_index", IfExists: true}))
+		assert.NoError(t, err)
+	}
+}
+
+func TestSQLiteClient_GeneratedColumnsToSql(t *testing.T) {
+	var (
+		model     = initGeneratedColumnsTestModel()
+		testCases = [...]expectation{
+			{
+				Expected: "CREATE TABLE testing (id INTEGER CONSTRAINT testing_pk primary key autoincrement, relation_id INTEGER NOT NULL, col1 INTEGER NOT NULL, col2 INTEGER NOT NULL, col3 VARCHAR NOT NULL, created_at DATETIME DEFAULT (datetime('now')) NOT NULL, total INTEGER GENERATED ALWAYS AS (col1 * col2) STORED NOT NULL, title VARCHAR GENERATED ALWAYS AS (upper(col3)) VIRTUAL NULL);",
+				Original: SQLiteClient{}.ToSql(new(Query).Create(&model)),
+			},
+			{
+				Expected: "ALTER TABLE testing ADD COLUMN updated_at DATETIME DEFAULT CURRENT_TIMESTAMP NULL",
+				Original: SQLiteClient{}.ToSql(new(Query).Alter(&model).AddColumn(dto.ModelField{
+					Name:       "updated_at",
+					Type:       "DATETIME",
+					Default:    dto.RawExpression("CURRENT_TIMESTAMP"),
+					IsNullable: true,
+				})),
+			},
+			{
+				Expected: "INSERT INTO testing (relation_id, col1, col2, col3, created_at) VALUES (?, ?, ?, ?, ?)",
+				Original: SQLiteClient{}.ToSql(new(Query).Insert(&model)),
+			},
+			{
+				Expected: "UPDATE testing SET relation_id = ?, col1 = ?, col2 = ?, col3 = ?, created_at = ?",
+				Original: SQLiteClient{}.ToSql(new(Query).Update(&model)),
+			},
+		}
+	)
+
+	for _, testCase := range testCases {
+		assert.Equal(t, testCase.Expected, testCase.Original)
+	}
+
+	model.UpdateFieldValue("id", 1)
+	assert.Equal(t, "UPDATE testing SET relation_id = ?, col1 = ?, col2 = ?, col3 = ?, created_at = ? WHERE id = ?", SQLiteClient{}.ToSql(new(Query).Save(&model)))
+}
+
+func TestSQLiteClient_ExecuteGeneratedColumns(t *testing.T) {
+	removeDatabase()
+	initDatabase()
+	defer removeDatabase()
+
+	sqliteClient, err := SQLiteClient{}.Connect(DatabaseConfig{
+		Host: testSQLiteDatabasePath,
+	})
+	assert.NoError(t, err)
+
+	model := initGeneratedColumnsTestModel()
+	_, err = sqliteClient.Execute(new(Query).Create(&model))
+	assert.NoError(t, err)
+
+	//The default expression is used for the column, which is not inserted
+	insertModel := initGeneratedColumnsTestModel()
+	insertModel.RemoveModelField("created_at")
+	_, err = sqliteClient.Execute(new(Query).Insert(&insertModel))
+	assert.NoError(t, err)
+
+	selectQuery := new(Query).Select([]interface{}{"total", "title", "created_at"}).From(&model)
+	res, err := sqliteClient.Execute(selectQuery)
+	assert.NoError(t, err)
+	assert.Len(t, res.Items(), 1)
+	assert.Equal(t, 4, res.Items()[0].GetField("total").Value)
+	assert.Equal(t, "TEST", res.Items()[0].GetField("title").Value)
+	assert.NotEmpty(t, res.Items()[0].GetField("created_at").Value)
+
+	//The generated columns are not updated by the saved model
+	savedModel := initGeneratedColumnsTestModel()
+	savedModel.UpdateFieldValue("id", 1)
+	savedModel.UpdateFieldValue("col2", 3)
+	savedModel.UpdateFieldValue("created_at", "2026-01-01 00:00:00")
+	_, err = sqliteClient.Execute(new(Query).Save(&savedModel))
+	assert.NoError(t, err)
+
+	res, err = sqliteClient.Execute(selectQuery)
+	assert.NoError(t, err)
+	assert.Len(t, res.Items(), 1)
+	assert.Equal(t, 6, res.Items()[0].GetField("total").Value)
+
+	//The generated columns are computed again after the table rebuild
+	_, err = sqliteClient.Execute(new(Query).Alter(&model).DropColumn(dto.ModelField{Name: "relation_id"}))
+	assert.NoError(t, err)
+
+	res, err = sqliteClient.Execute(selectQuery)
+	assert.NoError(t, err)
+	assert.Len(t, res.Items(), 1)
+	assert.Equal(t, 6, res.Items()[0].GetField("total").Value)
+	assert.Equal(t, "TEST", res.Items()[0].GetField("title").Value)
+
+	_, err = sqliteClient.Execute(new(Query).Alter(&model).AddColumn(dto.ModelField{
+		Name:     "updated_at",
+		Type:     "DATETIME",
+		Default:  dto.RawExpression("CURRENT_TIMESTAMP"),
+		OnUpdate: "CURRENT_TIMESTAMP",
+	}))
+	assert.ErrorContains(t, err, "ON UPDATE")
+
+	stored := dto.ModelField{
+		Name:          "double_total",
+		Type:          dto.IntegerColumnType,
+		Generated:     "total * 2",
+		GeneratedType: dto.StoredGeneratedType,
+	}
+	_, err = sqliteClient.Execute(new(Query).Alter(&model).AddColumn(stored))
+	assert.ErrorContains(t, err, "STORED generated column")
+
+	//The VIRTUAL generated column can be added, the STORED one can be added only during the table rebuild
+	virtual := stored
+	virtual.Name, virtual.GeneratedType = "triple_total", dto.VirtualGeneratedType
+	virtual.Generated = "total * 3"
+	_, err = sqliteClient.Execute(new(Query).Alter(&model).AddColumn(virtual))
+	assert.NoError(t, err)
+
+	_, err = sqliteClient.Execute(new(Query).Alter(&model).AddColumn(stored).DropColumn(dto.ModelField{Name: "triple_total"}))
+	assert.NoError(t, err)
+
+	var doubleTotal int
+	assert.NoError(t, sqliteClient.GetClient().QueryRow("SELECT double_total FROM testing").Scan(&doubleTotal))
+	assert.Equal(t, 12, doubleTotal)
+}
+
+func TestSQLiteClient_TableOptionsToSql(t *testing.T) {
+	model := initTableOptionsTestModel("settings")
+	assert.Equal(t, "CREATE TABLE settings (name TEXT CONSTRAINT settings_pk primary key, value TEXT COLLATE NOCASE NULL, position INTEGER DEFAULT 0 NOT NULL, label TEXT GENERATED ALWAYS AS (upper(value)) VIRTUAL NULL) STRICT, WITHOUT ROWID;", SQLiteClient{}.ToSql(new(Query).Create(&model)))
+
+	model.SetTableOptions(dto.TableOptions{Strict: true})
+	assert.Equal(t, "CREATE TABLE settings (name TEXT CONSTRAINT settings_pk primary key, value TEXT COLLATE NOCASE NULL, position INTEGER DEFAULT 0 NOT NULL, label TEXT GENERATED ALWAYS AS (upper(value)) VIRTUAL NULL) STRICT;", SQLiteClient{}.ToSql(new(Query).Create(&model)))
+
+	//The options of MySQL are ignored
+	model.SetTableOptions(dto.TableOptions{Engine: "InnoDB", Comment: "Settings"})
+	assert.Equal(t, "CREATE TABLE settings (name TEXT CONSTRAINT settings_pk primary key, value TEXT COLLATE NOCASE NULL, position INTEGER DEFAULT 0 NOT NULL, label TEXT GENERATED ALWAYS AS (upper(value)) VIRTUAL NULL);", SQLiteClient{}.ToSql(new(Query).Create(&model)))
+
+	assert.Equal(t, "INSERT INTO temp_settings (name, value) SELECT name, value FROM settings;", strings.Split(SQLiteClient{}.ToSql(new(Query).Alter(&model).DropColumn(dto.ModelField{Name: "position"})), "\n")[1])
+}
+
+func TestSQLiteClient_ExecuteTableOptions(t *testing.T) {
+	removeDatabase()
+	initDatabase()
+	defer removeDatabase()
+
+	sqliteClient, err := SQLiteClient{}.Connect(DatabaseConfig{
+		Host: testSQLiteDatabasePath,
+	})
+	assert.NoError(t, err)
+
+	model := initTableOptionsTestModel("settings")
+	_, err = sqliteClient.Execute(new(Query).Create(&model))
+	assert.NoError(t, err)
+
+	options, err := sqliteClient.TableOptions(context.Background(), "settings")
+	assert.NoError(t, err)
+	assert.Equal(t, dto.TableOptions{Strict: true, WithoutRowID: true}, options)
+
+	columns, err := sqliteClient.Columns(context.Background(), "settings")
+	assert.NoError(t, err)
+	assert.Equal(t, []dto.ModelField{
+		{Name: "name", Type: "TEXT", IsPrimaryKey: true},
+		{Name: "value", Type: "TEXT", Collate: "NOCASE", IsNullable: true},
+		{Name: "position", Type: dto.IntegerColumnType, Default: dto.RawExpression("0")},
+		{Name: "label", Type: "TEXT", Generated: "upper(value)", GeneratedType: dto.VirtualGeneratedType, IsNullable: true},
+	}, columns)
+
+	//The table can be created again using the introspected columns and options
+	copyModel := dto.BaseModel{TableName: "settings_copy", Options: options}
+	for _, column := range columns {
+		if column.IsPrimaryKey {
+			copyModel.SetPrimaryKey(column)
+			continue
+		}
+
+		copyModel.AddModelField(column)
+	}
+
+	_, err = sqliteClient.Execute(new(Query).Create(&copyModel))
+	assert.NoError(t, err)
+
+	copyColumns, err := sqliteClient.Columns(context.Background(), "settings_copy")
+	assert.NoError(t, err)
+	assert.Equal(t, columns, copyColumns)
+
+	//The strict table checks the types of the values
+	model.UpdateFieldValue("name", "limit")
+	model.UpdateFieldValue("position", "first")
+	_, err = sqliteClient.Execute(new(Query).Insert(&model))
+	assert.Error(t, err)
+
+	//The options of the table are kept after the table rebuild
+	_, err = sqliteClient.Execute(new(Query).Alter(&model).DropColumn(dto.ModelField{Name: "position"}))
+	assert.NoError(t, err)
+
+	options, err = sqliteClient.TableOptions(context.Background(), "settings")
+	assert.NoError(t, err)
+	assert.Equal(t, dto.TableOptions{Strict: true, WithoutRowID: true}, options)
+
+	_, err = sqliteClient.Columns(context.Background(), "unknown")
+	assert.Error(t, err)
+}
+
+func TestSQLiteClient_TemporaryTablesToSql(t *testing.T) {
+	var (
+		model       = initTestModel("test_table_name")
+		selectQuery = new(Query).Select([]interface{}{"id", "col3"}).From(&model).Where(query.Where{
+			First:    "col3",
+			Operator: "=",
+			Second:   query.Bind{Field: "col3", Value: "test"},
+		})
+		testCases = [...]expectation{
+			{
+				Expected: "CREATE TEMPORARY TABLE test_table_name (id INTEGER CONSTRAINT test_table_name_pk primary key autoincrement, relation_id INTEGER NOT NULL, col1 INTEGER NOT NULL, col2 INTEGER NOT NULL, col3 VARCHAR NOT NULL);",
+				Original: SQLiteClient{}.ToSql(new(Query).Create(&model).Temporary()),
+			},
+			{
+				Expected: "CREATE TEMPORARY TABLE IF NOT EXISTS staging AS SELECT id, col3 FROM test_table_name WHERE col3 = ?;",
+				Original: SQLiteClient{}.ToSql(new(Query).CreateFromSelect("staging", selectQuery).Temporary().IfNotExists()),
+			},
+			{
+				Expected: "CREATE TABLE staging AS SELECT id, col3 FROM test_table_name WHERE col3 = ?;",
+				Original: SQLiteClient{}.ToSql(new(Query).CreateFromSelect("staging", selectQuery)),
+			},
+		}
+	)
+
+	for _, testCase := range testCases {
+		assert.Equal(t, testCase.Expected, testCase.Original)
+	}
+
+	q := new(Query).CreateFromSelect("staging", selectQuery)
+	assert.Equal(t, []query.Bind{{Field: "col3", Value: "test"}}, q.GetBindings())
+}
+
+func TestSQLiteClient_ViewsToSql(t *testing.T) {
+	var reportQuery = new(Query).Select([]interface{}{"relation_id", "COUNT(id) AS total"}).
+		From("test_table_name").
+		GroupBy("relation_id")
+
+	assert.Equal(t, "CREATE VIEW report AS SELECT relation_id, COUNT(id) AS total FROM test_table_name GROUP BY relation_id",
+		SQLiteClient{}.ToSql(new(Query).CreateView("report", reportQuery)))
+	assert.Equal(t, "DROP VIEW IF EXISTS report;\nCREATE VIEW report AS SELECT relation_id, COUNT(id) AS total FROM test_table_name GROUP BY relation_id",
+		SQLiteClient{}.ToSql(new(Query).CreateView("report", reportQuery).OrReplace()))
+	assert.Equal(t, "DROP VIEW IF EXISTS report", SQLiteClient{}.ToSql(new(Query).DropView("report").IfExists()))
+}
+
+func TestSQLiteClient_ExecuteViews(t *testing.T) {
+	removeDatabase()
+	initDatabase()
+	defer removeDatabase()
+
+	sqliteClient, err := SQLiteClient{}.Connect(DatabaseConfig{
+		Host: testSQLiteDatabasePath,
+	})
+	assert.NoError(t, err)
+
+	model := initTestModel("testing")
+	_, err = sqliteClient.Execute(new(Query).Create(&model))
+	assert.NoError(t, err)
+
+	for i := 0; i < 3; i++ {
+		_, err = sqliteClient.Execute(new(Query).Insert(&model))
+		assert.NoError(t, err)
+	}
+
+	_, err = sqliteClient.Execute(new(Query).CreateView("testing_report", new(Query).
+		Select([]interface{}{"relation_id", "COUNT(id) AS total"}).
+		From(&model).
+		GroupBy("relation_id")))
+	assert.NoError(t, err)
+
+	res, err := sqliteClient.Execute(new(Query).Select([]interface{}{"relation_id", "total"}).From("testing_report"))
+	assert.NoError(t, err)
+	assert.Len(t, res.Items(), 1)
+	assert.Equal(t, 3, res.Items()[0].GetField("total").Value)
+
+	//The existing view cannot be created again without OrReplace
+	_, err = sqliteClient.Execute(new(Query).CreateView("testing_report", new(Query).Select([]interface{}{"id"}).From(&model)))
+	assert.Error(t, err)
+
+	_, err = sqliteClient.Execute(new(Query).CreateView("testing_report", new(Query).Select([]interface{}{"id"}).From(&model)).OrReplace())
+	assert.NoError(t, err)
+
+	_, err = sqliteClient.Execute(new(Query).CreateView("testing_filtered", new(Query).Select([]interface{}{"id"}).From(&model).Where(query.Eq("id", 1))))
+	assert.Error(t, err)
+
+	views, err := sqliteClient.Views(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, []dto.View{{Name: "testing_report", Definition: "SELECT id FROM testing"}}, views)
+
+	_, err = sqliteClient.Execute(new(Query).DropView("testing_report"))
+	assert.NoError(t, err)
+
+	_, err = sqliteClient.Execute(new(Query).DropView("testing_report"))
+	assert.Error(t, err)
+
+	_, err = sqliteClient.Execute(new(Query).DropView("testing_report").IfExists())
+	assert.NoError(t, err)
+
+	views, err = sqliteClient.Views(context.Background())
+	assert.NoError(t, err)
+	assert.Empty(t, views)
+}
+
+func TestSQLiteClient_TriggersToSql(t *testing.T) {
+	assert.Equal(t, "CREATE TRIGGER test_table_name_inserted AFTER INSERT ON test_table_name BEGIN UPDATE test_table_name SET col2 = 1 WHERE id = NEW.id; END;",
+		SQLiteClient{}.ToSql(new(Query).CreateTrigger(dto.Trigger{
+			Name:   "test_table_name_inserted",
+			Table:  "test_table_name",
+			Timing: dto.AfterTriggerTiming,
+			Event:  dto.InsertTriggerEvent,
+			Body:   "UPDATE test_table_name SET col2 = 1 WHERE id = NEW.id",
+		})))
+	assert.Equal(t, "DROP TRIGGER test_table_name_inserted", SQLiteClient{}.ToSql(new(Query).DropTrigger("test_table_name_inserted")))
+}
+
+func TestSQLiteClient_ExecuteTriggers(t *testing.T) {
+	removeDatabase()
+	initDatabase()
+	defer removeDatabase()
+
+	sqliteClient, err := SQLiteClient{}.Connect(DatabaseConfig{
+		Host: testSQLiteDatabasePath,
+	})
+	assert.NoError(t, err)
+
+	model := initTestModel("testing")
+	_, err = sqliteClient.Execute(new(Query).Create(&model))
+	assert.NoError(t, err)
+
+	trigger := dto.Trigger{
+		Name:   "testing_updated",
+		Table:  "testing",
+		Timing: dto.AfterTriggerTiming,
+		Event:  dto.UpdateTriggerEvent,
+		Body:   "UPDATE testing SET col2 = col2 + 1 WHERE id = NEW.id; UPDATE testing SET col3 = 'updated' WHERE id = NEW.id",
 	}
+	_, err = sqliteClient.Execute(new(Query).CreateTrigger(trigger))
+	assert.NoError(t, err)
+
+	triggers, err := sqliteClient.Triggers(context.Background(), "testing")
+	assert.NoError(t, err)
+	assert.Equal(t, []dto.Trigger{trigger}, triggers)
+
+	_, err = sqliteClient.Execute(new(Query).Insert(&model))
+	assert.NoError(t, err)
+
+	//The triggers are preserved during the table rebuild
+	_, err = sqliteClient.Execute(new(Query).Alter(&model).ModifyColumn(dto.ModelField{Name: "col1", Type: dto.IntegerColumnType, IsNullable: true}))
+	assert.NoError(t, err)
+
+	_, err = sqliteClient.Execute(new(Query).UpdateTable(&model).Set("col1", 10).Where(query.Eq("id", 1)))
+	assert.NoError(t, err)
+
+	res, err := sqliteClient.Execute(new(Query).Select([]interface{}{"col1", "col2", "col3"}).From(&model).Where(query.Eq("id", 1)))
+	assert.NoError(t, err)
+	assert.Len(t, res.Items(), 1)
+	assert.Equal(t, 10, res.Items()[0].GetField("col1").Value)
+	assert.Equal(t, 3, res.Items()[0].GetField("col2").Value)
+	assert.Equal(t, "updated", res.Items()[0].GetField("col3").Value)
+
+	_, err = sqliteClient.Execute(new(Query).DropTrigger(trigger.Name))
+	assert.NoError(t, err)
+
+	_, err = sqliteClient.Execute(new(Query).DropTrigger(trigger.Name).IfExists())
+	assert.NoError(t, err)
+
+	triggers, err = sqliteClient.Triggers(context.Background(), "testing")
+	assert.NoError(t, err)
+	assert.Empty(t, triggers)
 }
 
 func TestSQLiteClient_UpdateToSql(t *testing.T) {
@@ -627,7 +1998,7 @@ func TestSQLiteClient_UpdateToSql(t *testing.T) {
 				Original: SQLiteClient{}.ToSql(new(Query).Update(&model)),
 			},
 			{
-				Expected: "UPDATE test_table_name SET relation_id = ?, col1 = ?, col2 = ?, col3 = ? LEFT JOIN test ON (test.ref_id = test_table_name.id)",
+				Expected: "UPDATE test_table_name SET relation_id = ?, col1 = ?, col2 = ?, col3 = ? WHERE id IN (SELECT test_table_name.id FROM test_table_name LEFT JOIN test ON (test.ref_id = test_table_name.id))",
 				Original: SQLiteClient{}.ToSql(new(Query).Update(&model).Join(query.Join{
 					Target: query.Reference{
 						Table: "test",
@@ -649,6 +2020,18 @@ func TestSQLiteClient_UpdateToSql(t *testing.T) {
 					Second:   "test",
 				})),
 			},
+			{
+				Expected: "UPDATE test_table_name SET relation_id = ?, col1 = col1 + ?, col2 = ?, col3 = ? WHERE id = ?",
+				Original: SQLiteClient{}.ToSql(new(Query).Update(&model).Increment("col1", 1).Where(query.Eq("id", 1))),
+			},
+			{
+				Expected: "UPDATE test_table_name SET col1 = col1 - ?, col3 = CURRENT_TIMESTAMP, col2 = (SELECT MAX(col2) FROM test_table_name2) WHERE id = ?",
+				Original: SQLiteClient{}.ToSql(new(Query).UpdateTable("test_table_name").
+					Decrement("col1", 2).
+					Set("col3", query.Expression{SQL: "CURRENT_TIMESTAMP"}).
+					Set("col2", new(Query).Select([]interface{}{"MAX(col2)"}).From("test_table_name2")).
+					Where(query.Eq("id", 1))),
+			},
 		}
 	)
 
@@ -657,6 +2040,131 @@ func TestSQLiteClient_UpdateToSql(t *testing.T) {
 	}
 }
 
+func TestQuery_UpdateAssignments(t *testing.T) {
+	model := initTestModel("test_table_name")
+	q := new(Query).Update(&model).
+		Increment("col1", 5).
+		Set("col3", "changed").
+		Where(query.Eq("id", 10))
+
+	assert.Equal(t, "UPDATE test_table_name SET relation_id = ?, col1 = col1 + ?, col2 = ?, col3 = ? WHERE id = ?", SQLiteClient{}.ToSql(q))
+	assert.Equal(t, []query.Bind{
+		{Field: "?", Value: 1},
+		{Field: "col1", Value: 5},
+		{Field: "?", Value: 2},
+		{Field: "col3", Value: "changed"},
+		{Field: "id", Value: 10},
+	}, q.GetBindings())
+}
+
+func TestSQLiteClient_ExecuteIncrement(t *testing.T) {
+	removeDatabase()
+	initDatabase()
+	defer removeDatabase()
+
+	sqliteClient, err := SQLiteClient{}.Connect(DatabaseConfig{
+		Host: testSQLiteDatabasePath,
+	})
+	assert.NoError(t, err)
+
+	model := initTestModel("testing")
+	_, err = sqliteClient.Execute(new(Query).Create(&model))
+	assert.NoError(t, err)
+
+	_, err = sqliteClient.Execute(new(Query).Insert(&model))
+	assert.NoError(t, err)
+
+	for i := 0; i < 3; i++ {
+		_, err = sqliteClient.Execute(new(Query).UpdateTable(&model).Increment("col1", 10).Where(query.Eq("id", 1)))
+		assert.NoError(t, err)
+	}
+
+	_, err = sqliteClient.Execute(new(Query).UpdateTable(&model).Decrement("col2", 1).Where(query.Eq("id", 1)))
+	assert.NoError(t, err)
+
+	res, err := sqliteClient.Execute(new(Query).Select([]interface{}{"col1", "col2", "col3"}).From(&model).Where(query.Eq("id", 1)))
+	assert.NoError(t, err)
+	assert.Len(t, res.Items(), 1)
+	assert.Equal(t, 32, res.Items()[0].GetField("col1").Value)
+	assert.Equal(t, 1, res.Items()[0].GetField("col2").Value)
+	assert.Equal(t, "Test", res.Items()[0].GetField("col3").Value)
+}
+
+func TestQuery_UpdateDirty(t *testing.T) {
+	model := initTestModel("test_table_name")
+	model.UpdateFieldValue("id", 5)
+	model.SyncOriginal()
+	model.UpdateFieldValue("col1", 10)
+	model.UpdateFieldValue("col3", "changed")
+
+	q := new(Query).UpdateDirty(&model)
+	assert.Equal(t, "UPDATE test_table_name SET col1 = ?, col3 = ? WHERE id = ?", SQLiteClient{}.ToSql(q))
+	assert.Equal(t, []query.Bind{
+		{Field: "col1", Value: 10},
+		{Field: "col3", Value: "changed"},
+		{Field: "id", Value: 5},
+	}, q.GetBindings())
+
+	model.SyncOriginal()
+	assert.Equal(t, "", SQLiteClient{}.ToSql(new(Query).UpdateDirty(&model)))
+
+	//The model without the primary key cannot be updated, so nothing will be updated
+	withoutKey := dto.BaseModel{TableName: "logs", Fields: []interface{}{dto.ModelField{Name: "message", Value: "first"}}}
+	withoutKey.SyncOriginal()
+	withoutKey.UpdateFieldValue("message", "second")
+	q = new(Query).UpdateDirty(&withoutKey)
+	assert.Equal(t, "UPDATE logs SET message = ? WHERE 1 = 0", SQLiteClient{}.ToSql(q))
+	assert.Equal(t, []query.Bind{{Field: "message", Value: "second"}}, q.GetBindings())
+}
+
+func TestSQLiteClient_ExecuteUpdateDirty(t *testing.T) {
+	removeDatabase()
+	initDatabase()
+	defer removeDatabase()
+
+	sqliteClient, err := SQLiteClient{}.Connect(DatabaseConfig{
+		Host: testSQLiteDatabasePath,
+	})
+	assert.NoError(t, err)
+
+	model := initTestModel("testing")
+	_, err = sqliteClient.Execute(new(Query).Create(&model))
+	assert.NoError(t, err)
+
+	_, err = sqliteClient.Execute(new(Query).Insert(&model))
+	assert.NoError(t, err)
+
+	res, err := sqliteClient.Execute(new(Query).Select([]interface{}{}).From(&model).Where(query.Eq("id", 1)))
+	assert.NoError(t, err)
+	assert.Len(t, res.Items(), 1)
+
+	loaded := res.Items()[0]
+	assert.Equal(t, "testing", loaded.GetTableName())
+	assert.Equal(t, "id", loaded.GetPrimaryKey().Name)
+	assert.Equal(t, 1, loaded.GetPrimaryKey().Value)
+	assert.False(t, loaded.IsDirty())
+
+	//The concurrent change of the column, which is not changed in the loaded model
+	_, err = sqliteClient.Execute(new(Query).UpdateTable(&model).Set("col3", "concurrent").Where(query.Eq("id", 1)))
+	assert.NoError(t, err)
+
+	loaded.UpdateFieldValue("col1", 100)
+	assert.Equal(t, 2, loaded.Original("col1"))
+	_, err = sqliteClient.Execute(new(Query).UpdateDirty(loaded))
+	assert.NoError(t, err)
+
+	res, err = sqliteClient.Execute(new(Query).Select([]interface{}{"col1", "col3"}).From(&model).Where(query.Eq("id", 1)))
+	assert.NoError(t, err)
+	assert.Equal(t, 100, res.Items()[0].GetField("col1").Value)
+	assert.Equal(t, "concurrent", res.Items()[0].GetField("col3").Value)
+
+	//The clean model does not have the changes, so nothing is executed
+	loaded.SyncOriginal()
+	result, err := sqliteClient.Execute(new(Query).UpdateDirty(loaded))
+	assert.NoError(t, err)
+	assert.Equal(t, dto.BaseResult{}, result)
+}
+
 func TestSQLiteClient_DeleteToSql(t *testing.T) {
 	var (
 		model     = initTestModel("test_table_name")
@@ -667,7 +2175,7 @@ func TestSQLiteClient_DeleteToSql(t *testing.T) {
 				Original: SQLiteClient{}.ToSql(new(Query).Delete().From(&model)),
 			},
 			{
-				Expected: "DELETE FROM test_table_name LEFT JOIN test_table_name2 ON (test_table_name2.id = test_table_name.relation_id)",
+				Expected: "DELETE FROM test_table_name WHERE id IN (SELECT test_table_name.id FROM test_table_name LEFT JOIN test_table_name2 ON (test_table_name2.id = test_table_name.relation_id))",
 				Original: SQLiteClient{}.ToSql(new(Query).Delete().
 					From(&model).
 					Join(query.Join{
@@ -726,6 +2234,201 @@ func TestSQLiteClient_DeleteToSql(t *testing.T) {
 	}
 }
 
+func TestSQLiteClient_ExecuteJoinedUpdateAndDelete(t *testing.T) {
+	removeDatabase()
+	initDatabase()
+	defer removeDatabase()
+
+	sqliteClient, err := SQLiteClient{}.Connect(DatabaseConfig{
+		Host: testSQLiteDatabasePath,
+	})
+	assert.NoError(t, err)
+
+	model := initTestModel("testing")
+	_, err = sqliteClient.Execute(new(Query).Create(&model))
+	assert.NoError(t, err)
+
+	relation := initTestModel("relation")
+	_, err = sqliteClient.Execute(new(Query).Create(&relation))
+	assert.NoError(t, err)
+
+	for _, relationID := range []int{1, 2, 3} {
+		model.AddModelField(dto.ModelField{Name: "relation_id", Value: relationID})
+		_, err = sqliteClient.Execute(new(Query).Insert(&model))
+		assert.NoError(t, err)
+	}
+
+	for _, col3 := range []string{"keep", "remove"} {
+		relation.AddModelField(dto.ModelField{Name: "col3", Value: col3})
+		_, err = sqliteClient.Execute(new(Query).Insert(&relation))
+		assert.NoError(t, err)
+	}
+
+	join := query.Join{
+		Target:    query.Reference{Table: "relation", Key: "id"},
+		With:      query.Reference{Table: "testing", Key: "relation_id"},
+		Condition: "=",
+		Type:      query.InnerJoinType,
+	}
+
+	q := new(Query).UpdateTable(&model).
+		Set("col3", "updated").
+		Join(join).
+		Where(query.Eq("relation.col3", "keep"))
+	assert.Equal(t, "UPDATE testing SET col3 = ? WHERE id IN (SELECT testing.id FROM testing INNER JOIN relation ON (relation.id = testing.relation_id) WHERE relation.col3 = ?)", sqliteClient.ToSql(q))
+	_, err = sqliteClient.Execute(q)
+	assert.NoError(t, err)
+
+	_, err = sqliteClient.Execute(new(Query).Delete().From(&model).Join(join).Where(query.Eq("relation.col3", "remove")))
+	assert.NoError(t, err)
+
+	res, err := sqliteClient.Execute(new(Query).Select([]interface{}{"id", "col3"}).From(&model).OrderBy("id", query.OrderDirectionAsc))
+	assert.NoError(t, err)
+	assert.Len(t, res.Items(), 2)
+	assert.Equal(t, "updated", res.Items()[0].GetField("col3").Value)
+	assert.Equal(t, "Test", res.Items()[1].GetField("col3").Value)
+	assert.Equal(t, 3, res.Items()[1].GetField("id").Value)
+
+	//The rows of the table with the composite primary key are selected by the row values
+	groups := initCompositeTestModel()
+	groups.Options = dto.TableOptions{WithoutRowID: true}
+	_, err = sqliteClient.Execute(new(Query).Create(&groups))
+	assert.NoError(t, err)
+
+	for _, keys := range [][]int{{1, 1}, {1, 2}, {2, 1}} {
+		groups.AddModelField(dto.ModelField{Name: "user_id", Value: keys[0]})
+		groups.AddModelField(dto.ModelField{Name: "group_id", Value: keys[1]})
+		groups.AddModelField(dto.ModelField{Name: "email", Value: fmt.Sprintf("%d-%d@example.com", keys[0], keys[1])})
+		groups.AddModelField(dto.ModelField{Name: "position", Value: 0})
+		_, err = sqliteClient.Execute(new(Query).Insert(&groups))
+		assert.NoError(t, err)
+	}
+
+	q = new(Query).Delete().From(&groups).Join(query.Join{
+		Target:    query.Reference{Table: "relation", Key: "id"},
+		With:      query.Reference{Table: "user_groups", Key: "group_id"},
+		Condition: "=",
+		Type:      query.InnerJoinType,
+	}).Where(query.Eq("relation.col3", "remove"))
+	assert.Equal(t, "DELETE FROM user_groups WHERE (user_id, group_id) IN (SELECT user_groups.user_id, user_groups.group_id FROM user_groups INNER JOIN relation ON (relation.id = user_groups.group_id) WHERE relation.col3 = ?)", sqliteClient.ToSql(q))
+	_, err = sqliteClient.Execute(q)
+	assert.NoError(t, err)
+
+	res, err = sqliteClient.Execute(new(Query).Select([]interface{}{"email"}).From(&groups).OrderBy("email", query.OrderDirectionAsc))
+	assert.NoError(t, err)
+	assert.Len(t, res.Items(), 2)
+	assert.Equal(t, "1-1@example.com", res.Items()[0].GetField("email").Value)
+	assert.Equal(t, "2-1@example.com", res.Items()[1].GetField("email").Value)
+
+	//The WITHOUT ROWID table does not have the rowid, so its rows cannot be selected without the primary key
+	_, err = sqliteClient.Execute(new(Query).Delete().From(&dto.BaseModel{TableName: "user_groups", Options: dto.TableOptions{WithoutRowID: true}}).
+		Join(q.GetJoins()[0]).
+		Where(query.Eq("relation.col3", "keep")))
+	assert.ErrorContains(t, err, "WITHOUT ROWID")
+}
+
+func TestQuery_PrimaryKeyQueries(t *testing.T) {
+	model := initTestModel("test_table_name")
+	assert.Equal(t, "INSERT INTO test_table_name (relation_id, col1, col2, col3) VALUES (?, ?, ?, ?)", SQLiteClient{}.ToSql(new(Query).Save(&model)))
+
+	model.AddModelField(dto.ModelField{Name: "id", Value: 5})
+	q := new(Query).Save(&model)
+	assert.Equal(t, "UPDATE test_table_name SET relation_id = ?, col1 = ?, col2 = ?, col3 = ? WHERE id = ?", SQLiteClient{}.ToSql(q))
+	assert.Equal(t, query.Bind{Field: "id", Value: 5}, q.GetBindings()[4])
+
+	assert.Equal(t, "DELETE FROM test_table_name WHERE id = ?", SQLiteClient{}.ToSql(new(Query).DeleteModel(&model)))
+	assert.Equal(t, "SELECT * FROM test_table_name WHERE id = ?", SQLiteClient{}.ToSql(new(Query).Find(&model, 5)))
+
+	composite := dto.BaseModel{
+		TableName: "user_groups",
+		Fields: []interface{}{
+			dto.ModelField{Name: "user_id", Type: dto.IntegerColumnType, Value: 1, IsPrimaryKey: true},
+			dto.ModelField{Name: "group_id", Type: dto.IntegerColumnType, Value: 2, IsPrimaryKey: true},
+			dto.ModelField{Name: "role", Type: dto.VarcharColumnType, Value: "admin"},
+		},
+	}
+	q = new(Query).Save(&composite)
+	assert.Equal(t, "UPDATE user_groups SET role = ? WHERE user_id = ? AND group_id = ?", SQLiteClient{}.ToSql(q))
+	assert.Equal(t, []query.Bind{
+		{Field: "role", Value: "admin"},
+		{Field: "user_id", Value: 1},
+		{Field: "group_id", Value: 2},
+	}, q.GetBindings())
+	assert.Equal(t, "SELECT * FROM user_groups WHERE user_id = ? AND group_id = ?", SQLiteClient{}.ToSql(new(Query).Find(&composite, 1, 2)))
+
+	//The model without the primary key cannot be deleted, so nothing will be deleted
+	withoutKey := dto.BaseModel{TableName: "logs"}
+	assert.Equal(t, "DELETE FROM logs WHERE 1 = 0", SQLiteClient{}.ToSql(new(Query).DeleteModel(&withoutKey)))
+}
+
+func TestSQLiteClient_ExecutePrimaryKeyQueries(t *testing.T) {
+	removeDatabase()
+	initDatabase()
+	defer removeDatabase()
+
+	sqliteClient, err := SQLiteClient{}.Connect(DatabaseConfig{
+		Host: testSQLiteDatabasePath,
+	})
+	assert.NoError(t, err)
+
+	model := initTestModel("testing")
+	_, err = sqliteClient.Execute(new(Query).Create(&model))
+	assert.NoError(t, err)
+
+	res, err := sqliteClient.Execute(new(Query).Save(&model))
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), res.LastInsertID())
+
+	res, err = sqliteClient.Execute(new(Query).Find(&model, 1))
+	assert.NoError(t, err)
+	assert.Len(t, res.Items(), 1)
+
+	loaded := res.Items()[0]
+	loaded.UpdateFieldValue("col3", "saved")
+	_, err = sqliteClient.Execute(new(Query).Save(loaded))
+	assert.NoError(t, err)
+
+	res, err = sqliteClient.Execute(new(Query).Find(&model, 1))
+	assert.NoError(t, err)
+	assert.Equal(t, "saved", res.Items()[0].GetField("col3").Value)
+
+	_, err = sqliteClient.Execute(new(Query).DeleteModel(res.Items()[0]))
+	assert.NoError(t, err)
+
+	res, err = sqliteClient.Execute(new(Query).Find(&model, 1))
+	assert.NoError(t, err)
+	assert.Empty(t, res.Items())
+
+	//The missing primary key values are not compared with NULL, the wrong number of the values is the error
+	_, err = sqliteClient.Execute(new(Query).Find(&model))
+	assert.ErrorContains(t, err, "1 primary key columns, but 0 values")
+
+	_, err = sqliteClient.Execute(new(Query).Find(&model, 1, 2))
+	assert.ErrorContains(t, err, "1 primary key columns, but 2 values")
+
+	//The model, which has only the primary key columns, does not have the columns to update, so nothing is executed
+	membership := dto.BaseModel{TableName: "memberships", Fields: []interface{}{
+		dto.ModelField{Name: "user_id", Type: dto.IntegerColumnType, IsPrimaryKey: true, Value: 1},
+		dto.ModelField{Name: "group_id", Type: dto.IntegerColumnType, IsPrimaryKey: true, Value: 2},
+	}}
+	_, err = sqliteClient.Execute(new(Query).Create(&membership))
+	assert.NoError(t, err)
+
+	_, err = sqliteClient.Execute(new(Query).Insert(&membership))
+	assert.NoError(t, err)
+
+	res, err = sqliteClient.Execute(new(Query).Save(&membership))
+	assert.NoError(t, err)
+	assert.Equal(t, dto.BaseResult{}, res)
+
+	_, err = sqliteClient.Execute(new(Query).Find(&membership, 1))
+	assert.Error(t, err)
+
+	res, err = sqliteClient.Execute(new(Query).Find(&membership, 1, 2))
+	assert.NoError(t, err)
+	assert.Len(t, res.Items(), 1)
+}
+
 func TestSQLiteClient_Execute(t *testing.T) {
 	removeDatabase()
 	initDatabase()
@@ -972,6 +2675,55 @@ func initTestModel(table string) dto.BaseModel {
 	return model
 }
 
+func initCompositeTestModel() dto.BaseModel {
+	return dto.BaseModel{
+		TableName: "user_groups",
+		Fields: []interface{}{
+			dto.ModelField{Name: "user_id", Type: dto.IntegerColumnType, IsPrimaryKey: true},
+			dto.ModelField{Name: "group_id", Type: dto.IntegerColumnType, IsPrimaryKey: true},
+			dto.ModelField{Name: "email", Type: dto.VarcharColumnType},
+			dto.ModelField{Name: "position", Type: dto.IntegerColumnType},
+		},
+	}
+}
+
+func initGeneratedColumnsTestModel() dto.BaseModel {
+	model := initTestModel("testing")
+	model.AddModelField(dto.ModelField{
+		Name:    "created_at",
+		Type:    "DATETIME",
+		Default: dto.RawExpression("(datetime('now'))"),
+	})
+	model.AddModelField(dto.ModelField{
+		Name:          "total",
+		Type:          dto.IntegerColumnType,
+		Generated:     "col1 * col2",
+		GeneratedType: dto.StoredGeneratedType,
+	})
+	model.AddModelField(dto.ModelField{
+		Name:          "title",
+		Type:          dto.VarcharColumnType,
+		Generated:     "upper(col3)",
+		GeneratedType: dto.VirtualGeneratedType,
+		IsNullable:    true,
+	})
+
+	return model
+}
+
+func initTableOptionsTestModel(table string) dto.BaseModel {
+	model := dto.BaseModel{
+		TableName: table,
+		Options:   dto.TableOptions{Strict: true, WithoutRowID: true},
+	}
+	model.SetPrimaryKey(dto.ModelField{Name: "name", Type: "TEXT"})
+	model.AddModelField(dto.ModelField{Name: "value", Type: "TEXT", Collate: "NOCASE", IsNullable: true})
+	model.AddModelField(dto.ModelField{Name: "position", Type: dto.IntegerColumnType, Default: dto.RawExpression("0")})
+	model.AddModelField(dto.ModelField{Name: "label", Type: "TEXT", Generated: "upper(value)", GeneratedType: dto.VirtualGeneratedType, IsNullable: true})
+
+	return model
+}
+
 func TestFromInterfaceUsage(t *testing.T) {
 	var actual string
 	q := new(Query).Select([]interface{}{"id", "name"}).From("test_table")
@@ -990,6 +2742,70 @@ func TestSQLiteClient_Transactions(t *testing.T) {
 	assert.Equal(t, "ROLLBACK;", SQLiteClient{}.ToSql(new(Query).RollbackTransaction()))
 }
 
+func TestSQLiteClient_ExecuteSession(t *testing.T) {
+	removeDatabase()
+	initDatabase()
+	defer removeDatabase()
+
+	sqliteClient, err := SQLiteClient{}.Connect(DatabaseConfig{
+		Host: testSQLiteDatabasePath,
+	})
+	assert.NoError(t, err)
+	defer sqliteClient.Disconnect()
+
+	model := initTestModel("testing")
+	_, err = sqliteClient.Execute(new(Query).Create(&model))
+	assert.NoError(t, err)
+
+	for _, value := range []string{"first", "second", "first"} {
+		model.AddModelField(dto.ModelField{Name: "col3", Value: value})
+		_, err = sqliteClient.Execute(new(Query).Insert(&model))
+		assert.NoError(t, err)
+	}
+
+	session, err := sqliteClient.Session(context.Background())
+	assert.NoError(t, err)
+
+	_, err = session.Session(context.Background())
+	assert.Error(t, err)
+
+	_, err = session.Execute(new(Query).CreateFromSelect("staging", new(Query).
+		Select([]interface{}{"id", "col3"}).
+		From(&model).
+		Where(query.Where{
+			First:    "col3",
+			Operator: "=",
+			Second:   query.Bind{Field: "col3", Value: "first"},
+		})).Temporary())
+	assert.NoError(t, err)
+
+	res, err := session.Execute(new(Query).Select([]interface{}{"id"}).From("staging"))
+	assert.NoError(t, err)
+	assert.Len(t, res.Items(), 2)
+
+	//The temporary table is visible only for the connection of the session
+	conn, err := sqliteClient.GetClient().Conn(context.Background())
+	assert.NoError(t, err)
+	_, err = conn.ExecContext(context.Background(), "SELECT id FROM staging")
+	assert.Error(t, err)
+	assert.NoError(t, conn.Close())
+
+	//The alter of the table is executed using the connection of the session
+	_, err = session.Execute(new(Query).Alter(&model).DropColumn(dto.ModelField{Name: "col2"}))
+	assert.NoError(t, err)
+
+	res, err = session.Execute(new(Query).Select([]interface{}{"id"}).From("staging"))
+	assert.NoError(t, err)
+	assert.Len(t, res.Items(), 2)
+
+	assert.NoError(t, session.Disconnect())
+
+	//The pool is still available after the session is closed
+	res, err = sqliteClient.Execute(new(Query).Select([]interface{}{"id"}).From(&model))
+	assert.NoError(t, err)
+	assert.Len(t, res.Items(), 3)
+}
+
 func initDatabase() {
 	_, err := os.Create(testSQLiteDatabasePath)
 	if err != nil {
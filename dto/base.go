@@ -1,11 +1,29 @@
 package dto
 
-// ModelField interface for the model fields
+const (
+	StoredGeneratedType  = "STORED"
+	VirtualGeneratedType = "VIRTUAL"
+)
+
+// RawExpression the raw sql expression, which is used in the column definition without the quotes. Eg: RawExpression("CURRENT_TIMESTAMP")
+type RawExpression string
+
+// ModelField interface for the model fields.
+// The Default can be the RawExpression for the default expressions, eg: RawExpression("(datetime('now'))").
+// The OnUpdate is the raw sql expression of the MySQL ON UPDATE clause, eg: "CURRENT_TIMESTAMP".
+// The Generated is the expression of the generated column, eg: "price * quantity", and the GeneratedType is StoredGeneratedType or VirtualGeneratedType.
+// The Charset and Comment are used only by MySQL, the Collate is used by MySQL and SQLite
 type ModelField struct {
 	Name          string
 	Type          string
 	Value         interface{}
 	Default       interface{}
+	OnUpdate      string
+	Generated     string
+	GeneratedType string
+	Charset       string
+	Collate       string
+	Comment       string
 	Length        int64
 	IsNullable    bool
 	IsPrimaryKey  bool
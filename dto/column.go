@@ -12,3 +12,9 @@ type Column struct {
 	Field ModelField
 	Alias string
 }
+
+// ColumnRename the rename of the table column, which can be used in the alter query
+type ColumnRename struct {
+	From string
+	To   string
+}
@@ -0,0 +1,16 @@
+package dto
+
+const (
+	PrimaryKeyConstraint = "PRIMARY KEY"
+	UniqueConstraint     = "UNIQUE"
+	CheckConstraint      = "CHECK"
+)
+
+// Constraint the table-level constraint. For the PRIMARY KEY and UNIQUE constraints the Columns should be specified, for the CHECK constraint the Expression should be specified.
+// Eg: Constraint{Name: "users_email_uq", Type: UniqueConstraint, Columns: []string{"account_id", "email"}}
+type Constraint struct {
+	Name       string
+	Type       string
+	Columns    []string
+	Expression string
+}
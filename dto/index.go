@@ -8,19 +8,44 @@ const (
 	SetNullAction  = "SET NULL"
 )
 
+const (
+	BTreeIndexType    = "BTREE"
+	HashIndexType     = "HASH"
+	FullTextIndexType = "FULLTEXT"
+	SpatialIndexType  = "SPATIAL"
+)
+
+// Index the index of the table. The Columns should be used for the composite, ordered and expression indexes, otherwise the Key is used.
+// The Where predicate can be used for the partial indexes in SQLite. The Type can be used for the index type in MySQL, eg: BTREE, HASH, FULLTEXT or SPATIAL
 type Index struct {
-	Name   string
-	Target string
-	Key    string
-	Unique bool
+	Name        string
+	Target      string
+	Key         string
+	Unique      bool
+	Columns     []IndexColumn
+	Where       string
+	Type        string
+	IfNotExists bool
+	IfExists    bool
+}
+
+// IndexColumn the column of the index. If the Expression is specified, the expression index will be generated.
+// The Length can be used for the prefix length of the column in MySQL
+type IndexColumn struct {
+	Name       string
+	Expression string
+	Direction  string
+	Length     int64
 }
 
+// ForeignKey the foreign key of the table. The IfExists flag can be used for the drop of the foreign key
 type ForeignKey struct {
 	Name     string
 	Target   query.Reference
 	With     query.Reference
 	OnDelete string
 	OnUpdate string
+	IfExists bool
 }
 
 func (f ForeignKey) GetOnDelete() string {
@@ -1,5 +1,7 @@
 package dto
 
+import "reflect"
+
 // ModelInterface the main interface for the object model
 type ModelInterface interface {
 	GetTableName() string
@@ -11,12 +13,35 @@ type ModelInterface interface {
 	RemoveModelField(fieldName string) ModelInterface
 	GetPrimaryKey() ModelField
 	SetPrimaryKey(ModelField)
+
+	//GetPrimaryKeys returns the columns of the primary key. For the composite primary key the fields with IsPrimaryKey flag are returned
+	GetPrimaryKeys() []ModelField
+
+	//IsDirty returns true if at least one field was changed after the model was loaded from the database
+	IsDirty() bool
+
+	//DirtyFields returns the fields, which were changed after the model was loaded from the database
+	DirtyFields() []ModelField
+
+	//Original returns the value of the field, which was loaded from the database
+	Original(name string) interface{}
+
+	//SyncOriginal remembers the current values of the fields as the original values
+	SyncOriginal()
+
+	//GetTableOptions returns the options of the table, which are used in the CREATE TABLE statement
+	GetTableOptions() TableOptions
+
+	//SetTableOptions sets the options of the table, eg: engine, charset, collation or comment
+	SetTableOptions(TableOptions)
 }
 
 type BaseModel struct {
 	TableName  string
 	PrimaryKey ModelField
 	Fields     []interface{}
+	Options    TableOptions
+	originals  map[string]interface{}
 }
 
 func (m *BaseModel) SetTableName(name string) {
@@ -90,6 +115,16 @@ func (m *BaseModel) UpdateFieldValue(name string, value interface{}) {
 	m.Fields = columns
 }
 
+// GetTableOptions returns the options of the table, which are used in the CREATE TABLE statement
+func (m *BaseModel) GetTableOptions() TableOptions {
+	return m.Options
+}
+
+// SetTableOptions sets the options of the table, eg: engine, charset, collation or comment
+func (m *BaseModel) SetTableOptions(options TableOptions) {
+	m.Options = options
+}
+
 func (m *BaseModel) GetPrimaryKey() ModelField {
 	return m.PrimaryKey
 }
@@ -100,6 +135,33 @@ func (m *BaseModel) SetPrimaryKey(field ModelField) {
 	m.AddModelField(field)
 }
 
+// GetPrimaryKeys returns the columns of the primary key. For the composite primary key the fields with IsPrimaryKey flag are returned in the order of the model fields
+func (m *BaseModel) GetPrimaryKeys() []ModelField {
+	var (
+		keys   []ModelField
+		exists = false
+	)
+	for _, field := range m.GetColumns() {
+		switch v := field.(type) {
+		case ModelField:
+			if m.PrimaryKey.Name != "" && v.Name == m.PrimaryKey.Name {
+				v.IsPrimaryKey = true
+				exists = true
+			}
+
+			if v.IsPrimaryKey {
+				keys = append(keys, v)
+			}
+		}
+	}
+
+	if m.PrimaryKey.Name != "" && !exists {
+		keys = append([]ModelField{m.PrimaryKey}, keys...)
+	}
+
+	return keys
+}
+
 func (m *BaseModel) RemoveModelField(field string) ModelInterface {
 	var columns []interface{}
 	for _, f := range m.Fields {
@@ -117,3 +179,43 @@ func (m *BaseModel) RemoveModelField(field string) ModelInterface {
 
 	return m
 }
+
+// IsDirty returns true if at least one field was changed after the model was loaded from the database
+func (m *BaseModel) IsDirty() bool {
+	return len(m.DirtyFields()) > 0
+}
+
+// DirtyFields returns the fields, which were changed after the model was loaded from the database.
+// If the original value of the field is unknown, the field is treated as changed
+func (m *BaseModel) DirtyFields() []ModelField {
+	var fields []ModelField
+	for _, field := range m.GetColumns() {
+		switch v := field.(type) {
+		case ModelField:
+			original, exists := m.originals[v.Name]
+			if exists && reflect.DeepEqual(original, v.Value) {
+				continue
+			}
+
+			fields = append(fields, v)
+		}
+	}
+
+	return fields
+}
+
+// Original returns the value of the field, which was loaded from the database
+func (m *BaseModel) Original(name string) interface{} {
+	return m.originals[name]
+}
+
+// SyncOriginal remembers the current values of the fields as the original values. It is called for the models selected from the database
+func (m *BaseModel) SyncOriginal() {
+	m.originals = map[string]interface{}{}
+	for _, field := range m.GetColumns() {
+		switch v := field.(type) {
+		case ModelField:
+			m.originals[v.Name] = v.Value
+		}
+	}
+}
@@ -108,3 +108,75 @@ func TestBaseModel_AddModelField(t *testing.T) {
 		AutoIncrement: true,
 	}, model.GetPrimaryKey())
 }
+
+func TestBaseModel_DirtyFields(t *testing.T) {
+	model := new(BaseModel)
+	model.SetPrimaryKey(ModelField{
+		Name:  "id",
+		Type:  IntegerColumnType,
+		Value: 1,
+	})
+	model.AddModelField(ModelField{
+		Name:  "name",
+		Type:  VarcharColumnType,
+		Value: "test",
+	})
+	model.AddModelField(ModelField{
+		Name:  "data",
+		Type:  VarcharColumnType,
+		Value: []byte("data"),
+	})
+
+	//The original values are unknown, so all fields are changed
+	assert.True(t, model.IsDirty())
+	assert.Len(t, model.DirtyFields(), 3)
+	assert.Nil(t, model.Original("name"))
+
+	model.SyncOriginal()
+	assert.False(t, model.IsDirty())
+	assert.Empty(t, model.DirtyFields())
+
+	model.UpdateFieldValue("data", []byte("data"))
+	assert.False(t, model.IsDirty())
+
+	model.UpdateFieldValue("name", "changed")
+	assert.True(t, model.IsDirty())
+	assert.Equal(t, []ModelField{{
+		Name:  "name",
+		Type:  VarcharColumnType,
+		Value: "changed",
+	}}, model.DirtyFields())
+	assert.Equal(t, "test", model.Original("name"))
+	assert.Equal(t, "changed", model.GetField("name").Value)
+}
+
+func TestBaseModel_GetPrimaryKeys(t *testing.T) {
+	model := new(BaseModel)
+	assert.Empty(t, model.GetPrimaryKeys())
+
+	model.SetPrimaryKey(ModelField{
+		Name: "id",
+		Type: IntegerColumnType,
+	})
+	assert.Equal(t, []ModelField{{Name: "id", Type: IntegerColumnType, IsPrimaryKey: true}}, model.GetPrimaryKeys())
+
+	composite := BaseModel{
+		Fields: []interface{}{
+			ModelField{Name: "user_id", Type: IntegerColumnType, IsPrimaryKey: true},
+			ModelField{Name: "name", Type: VarcharColumnType},
+			ModelField{Name: "group_id", Type: IntegerColumnType, IsPrimaryKey: true},
+		},
+	}
+	assert.Equal(t, []ModelField{
+		{Name: "user_id", Type: IntegerColumnType, IsPrimaryKey: true},
+		{Name: "group_id", Type: IntegerColumnType, IsPrimaryKey: true},
+	}, composite.GetPrimaryKeys())
+}
+
+func TestBaseModel_TableOptions(t *testing.T) {
+	model := BaseModel{TableName: "users"}
+	assert.Equal(t, TableOptions{}, model.GetTableOptions())
+
+	model.SetTableOptions(TableOptions{Engine: "InnoDB", Comment: "The users"})
+	assert.Equal(t, TableOptions{Engine: "InnoDB", Comment: "The users"}, model.GetTableOptions())
+}
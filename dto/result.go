@@ -39,3 +39,20 @@ func (r *BaseResult) LastInsertID() int64 {
 func (r *BaseResult) SetLastInsertID(id int64) {
 	r.InsertID = id
 }
+
+// PageResult the result of the offset pagination
+type PageResult struct {
+	Items   []ModelInterface
+	Total   int64
+	Page    int64
+	PerPage int64
+}
+
+// TotalPages returns the number of the pages
+func (r PageResult) TotalPages() int64 {
+	if r.PerPage <= 0 {
+		return 0
+	}
+
+	return (r.Total + r.PerPage - 1) / r.PerPage
+}
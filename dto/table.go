@@ -0,0 +1,13 @@
+package dto
+
+// TableOptions the options of the table. The Engine, Charset, Collate, Comment and AutoIncrement options are used by MySQL, the WithoutRowID and Strict options are used by SQLite.
+// Eg: TableOptions{Engine: "InnoDB", Charset: "utf8mb4", Collate: "utf8mb4_unicode_ci", Comment: "The users of the application", AutoIncrement: 1000}
+type TableOptions struct {
+	Engine        string
+	Charset       string
+	Collate       string
+	Comment       string
+	AutoIncrement int64
+	WithoutRowID  bool
+	Strict        bool
+}
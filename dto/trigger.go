@@ -0,0 +1,21 @@
+package dto
+
+const (
+	BeforeTriggerTiming    = "BEFORE"
+	AfterTriggerTiming     = "AFTER"
+	InsteadOfTriggerTiming = "INSTEAD OF"
+
+	InsertTriggerEvent = "INSERT"
+	UpdateTriggerEvent = "UPDATE"
+	DeleteTriggerEvent = "DELETE"
+)
+
+// Trigger the trigger of the table. The Body contains the statements, which are executed for each row, eg:
+// Trigger{Name: "users_updated_at", Table: "users", Timing: AfterTriggerTiming, Event: UpdateTriggerEvent, Body: "UPDATE users SET updated_at = CURRENT_TIMESTAMP WHERE id = NEW.id"}
+type Trigger struct {
+	Name   string
+	Table  string
+	Timing string
+	Event  string
+	Body   string
+}
@@ -0,0 +1,7 @@
+package dto
+
+// View the view of the database. The Definition contains the SELECT statement of the view
+type View struct {
+	Name       string
+	Definition string
+}
@@ -0,0 +1,8 @@
+package query
+
+// Assignment the column assignment of the UPDATE query.
+// The Value can be the Expression, eg: Expression{SQL: "counter + ?", Bindings: []Bind{...}}, the nested select query or the value, which will be bound
+type Assignment struct {
+	Column string
+	Value  interface{}
+}
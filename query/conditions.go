@@ -0,0 +1,154 @@
+package query
+
+// The operators which can be used in the conditions
+const (
+	EqualOperator      = "="
+	NotEqualOperator   = "<>"
+	LessOperator       = "<"
+	LessEqualOperator  = "<="
+	GreaterOperator    = ">"
+	GreaterEqOperator  = ">="
+	LikeOperator       = "LIKE"
+	NotLikeOperator    = "NOT LIKE"
+	BetweenOperator    = "BETWEEN"
+	IsOperator         = "IS"
+	IsNotOperator      = "IS NOT"
+	NotOperator        = "NOT"
+	ExistsOperator     = "EXISTS"
+	NotExistsOperator  = "NOT EXISTS"
+	nullValue          = "NULL"
+	betweenPlaceholder = "? AND ?"
+)
+
+// Group the list of conditions, which will be wrapped in the brackets in the generated query.
+// The Type of each condition is used as the logical operator in front of it
+type Group []Where
+
+// Expression the raw sql expression with the bindings for its placeholders. Eg: Expression{SQL: "? AND ?", Bindings: []Bind{...}}
+type Expression struct {
+	SQL      string
+	Bindings []Bind
+}
+
+// And groups the conditions with AND logical operator
+func And(conditions ...Where) Where {
+	return group(WhereAndType, conditions)
+}
+
+// Or groups the conditions with OR logical operator
+func Or(conditions ...Where) Where {
+	return group(WhereOrType, conditions)
+}
+
+// Not negates the condition
+func Not(condition Where) Where {
+	var negated = Group{condition}
+	switch v := condition.First.(type) {
+	case Group:
+		//The group of conditions is already wrapped in the brackets
+		if condition.Operator == "" && condition.Second == nil {
+			negated = v
+		}
+	}
+
+	return Where{
+		Operator: NotOperator,
+		Second:   negated,
+	}
+}
+
+// Eq generates the "column = ?" condition
+func Eq(column string, value interface{}) Where {
+	return compare(column, EqualOperator, value)
+}
+
+// NotEq generates the "column <> ?" condition
+func NotEq(column string, value interface{}) Where {
+	return compare(column, NotEqualOperator, value)
+}
+
+// Lt generates the "column < ?" condition
+func Lt(column string, value interface{}) Where {
+	return compare(column, LessOperator, value)
+}
+
+// Lte generates the "column <= ?" condition
+func Lte(column string, value interface{}) Where {
+	return compare(column, LessEqualOperator, value)
+}
+
+// Gt generates the "column > ?" condition
+func Gt(column string, value interface{}) Where {
+	return compare(column, GreaterOperator, value)
+}
+
+// Gte generates the "column >= ?" condition
+func Gte(column string, value interface{}) Where {
+	return compare(column, GreaterEqOperator, value)
+}
+
+// Like generates the "column LIKE ?" condition
+func Like(column string, pattern string) Where {
+	return compare(column, LikeOperator, pattern)
+}
+
+// NotLike generates the "column NOT LIKE ?" condition
+func NotLike(column string, pattern string) Where {
+	return compare(column, NotLikeOperator, pattern)
+}
+
+// Between generates the "column BETWEEN ? AND ?" condition
+func Between(column string, from interface{}, to interface{}) Where {
+	return Where{
+		First:    column,
+		Operator: BetweenOperator,
+		Second: Expression{
+			SQL: betweenPlaceholder,
+			Bindings: []Bind{
+				{Field: column, Value: from},
+				{Field: column, Value: to},
+			},
+		},
+	}
+}
+
+// IsNull generates the "column IS NULL" condition
+func IsNull(column string) Where {
+	return Where{
+		First:    column,
+		Operator: IsOperator,
+		Second:   nullValue,
+	}
+}
+
+// IsNotNull generates the "column IS NOT NULL" condition
+func IsNotNull(column string) Where {
+	return Where{
+		First:    column,
+		Operator: IsNotOperator,
+		Second:   nullValue,
+	}
+}
+
+func compare(column string, operator string, value interface{}) Where {
+	return Where{
+		First:    column,
+		Operator: operator,
+		Second: Bind{
+			Field: column,
+			Value: value,
+		},
+	}
+}
+
+func group(groupType string, conditions []Where) Where {
+	var items Group
+	for _, condition := range conditions {
+		condition.Type = groupType
+		items = append(items, condition)
+	}
+
+	return Where{
+		First: items,
+	}
+}
@@ -4,14 +4,23 @@ const (
 	LeftJoinType  = "LEFT"
 	RightJoinType = "RIGHT"
 	InnerJoinType = "INNER"
+	CrossJoinType = "CROSS"
+	FullJoinType  = "FULL"
 )
 
-// Join the object which will be used in JOIN clause generation
+// Join the object which will be used in JOIN clause generation.
+// If the Query is specified, the nested select query will be used as join target and the Target.Table will be used as its alias, if the Alias is not specified.
+// The On conditions are added to the Target.Key and With.Key comparison, if the Target.Key is specified. Otherwise, only On conditions are used.
+// If the Using columns are specified, the JOIN ... USING (columns) clause will be generated.
 type Join struct {
 	Target    Reference
 	With      Reference
 	Condition string
 	Type      string
+	Query     interface{}
+	Alias     string
+	On        []Where
+	Using     []string
 }
 
 // Reference the reference table struct. It can be used for definition of the related table in the join clause
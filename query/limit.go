@@ -1,7 +1,11 @@
 package query
 
-// Limit is the struct which describes the query result limitations
+// Limit is the struct which describes the query result limitations.
+// The From and To fields are generated as "LIMIT From, To", where To is the number of rows. Please use Offset and Count fields instead, which are generated as "LIMIT Count OFFSET Offset".
+// These pairs of fields cannot be mixed, otherwise the query is rejected by the client
 type Limit struct {
-	From int64
-	To   int64
+	From   int64
+	To     int64
+	Offset int64
+	Count  int64
 }
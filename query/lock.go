@@ -0,0 +1,15 @@
+package query
+
+const (
+	ForUpdateLock = "FOR UPDATE"
+	ForShareLock  = "FOR SHARE"
+
+	NoWaitLockOption     = "NOWAIT"
+	SkipLockedLockOption = "SKIP LOCKED"
+)
+
+// Lock is the struct which describes the row locking clause of the select query. It is generated as "FOR UPDATE NOWAIT", "FOR SHARE SKIP LOCKED", etc.
+type Lock struct {
+	Strength string
+	Option   string
+}
@@ -1,12 +1,23 @@
 package query
 
+import (
+	"database/sql/driver"
+	"reflect"
+)
+
 // You can use this types for building of WHERE clause
 const (
 	WhereAndType = "AND"
 	WhereOrType  = "OR"
 	WhereNotType = "NOT"
+
+	InOperator    = "IN"
+	NotInOperator = "NOT IN"
 )
 
+// InChunkSize the maximum number of values in one IN list. The bigger lists will be split into the several IN lists
+var InChunkSize = 1000
+
 // Where is an object which will be used for WHERE clause generation
 type Where struct {
 	First    interface{}
@@ -21,3 +32,88 @@ func (w Where) GetType() string {
 	}
 	return w.Type
 }
+
+// In generates the "column IN (?, ?, ?)" condition, where each element of the values slice will be bound to the query.
+// The []byte values and the values implementing driver.Valuer, eg: json.RawMessage, are bound as one value.
+// For the empty slice the always-false condition will be generated.
+func In(column string, values interface{}) Where {
+	return inWhere(column, InOperator, WhereOrType, values)
+}
+
+// NotIn generates the "column NOT IN (?, ?, ?)" condition, where each element of the values slice will be bound to the query.
+// For the empty slice the always-true condition will be generated.
+func NotIn(column string, values interface{}) Where {
+	return inWhere(column, NotInOperator, WhereAndType, values)
+}
+
+func inWhere(column string, operator string, chunksType string, values interface{}) Where {
+	var binds []Bind
+	v := reflect.ValueOf(values)
+	switch {
+	case isListValue(v):
+		for i := 0; i < v.Len(); i++ {
+			binds = append(binds, Bind{
+				Field: column,
+				Value: v.Index(i).Interface(),
+			})
+		}
+	case values != nil:
+		binds = append(binds, Bind{
+			Field: column,
+			Value: values,
+		})
+	}
+
+	if len(binds) == 0 {
+		if operator == InOperator {
+			return Where{First: "1", Operator: "=", Second: "0"}
+		}
+
+		return Where{First: "1", Operator: "=", Second: "1"}
+	}
+
+	var result Where
+	for i := 0; i < len(binds); i += InChunkSize {
+		end := i + InChunkSize
+		if InChunkSize <= 0 || end > len(binds) {
+			end = len(binds)
+		}
+
+		chunk := Where{
+			First:    column,
+			Operator: operator,
+			Second:   binds[i:end],
+		}
+
+		if i == 0 {
+			result = chunk
+		} else {
+			chunk.Type = chunksType
+			result = Where{
+				First:  result,
+				Second: chunk,
+			}
+		}
+
+		if end == len(binds) {
+			break
+		}
+	}
+
+	return result
+}
+
+// isListValue checks if the value is the list of the values for IN condition.
+// The []byte values and the values implementing driver.Valuer are the single values, eg: json.RawMessage
+func isListValue(v reflect.Value) bool {
+	if v.Kind() != reflect.Slice && v.Kind() != reflect.Array {
+		return false
+	}
+
+	if v.Kind() == reflect.Slice && v.Type().Elem().Kind() == reflect.Uint8 {
+		return false
+	}
+
+	_, isValuer := v.Interface().(driver.Valuer)
+	return !isValuer
+}
@@ -0,0 +1,97 @@
+package query
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIn(t *testing.T) {
+	assert.Equal(t, Where{
+		First:    "id",
+		Operator: InOperator,
+		Second: []Bind{
+			{Field: "id", Value: 1},
+			{Field: "id", Value: 2},
+		},
+	}, In("id", []int{1, 2}))
+
+	assert.Equal(t, Where{
+		First:    "id",
+		Operator: NotInOperator,
+		Second: []Bind{
+			{Field: "id", Value: "a"},
+		},
+	}, NotIn("id", []interface{}{"a"}))
+
+	//Empty lists should generate the always-false and always-true conditions
+	assert.Equal(t, Where{First: "1", Operator: "=", Second: "0"}, In("id", []int{}))
+	assert.Equal(t, Where{First: "1", Operator: "=", Second: "1"}, NotIn("id", nil))
+}
+
+// testTags the list, which is stored as one value
+type testTags []string
+
+func (t testTags) Value() (driver.Value, error) {
+	return strings.Join(t, ","), nil
+}
+
+func TestIn_ScalarValues(t *testing.T) {
+	//The bytes and the values implementing driver.Valuer are bound as one value
+	for _, value := range []interface{}{[]byte("abc"), json.RawMessage(`{"a":1}`), testTags{"a", "b"}} {
+		assert.Equal(t, Where{
+			First:    "data",
+			Operator: InOperator,
+			Second:   []Bind{{Field: "data", Value: value}},
+		}, In("data", value))
+	}
+
+	//The elements of the list are bound separately
+	assert.Equal(t, Where{
+		First:    "data",
+		Operator: NotInOperator,
+		Second: []Bind{
+			{Field: "data", Value: []byte("a")},
+			{Field: "data", Value: json.RawMessage("1")},
+		},
+	}, NotIn("data", []interface{}{[]byte("a"), json.RawMessage("1")}))
+}
+
+func TestIn_Chunks(t *testing.T) {
+	defaultChunkSize := InChunkSize
+	InChunkSize = 2
+	defer func() {
+		InChunkSize = defaultChunkSize
+	}()
+
+	assert.Equal(t, Where{
+		First: Where{
+			First:    "id",
+			Operator: InOperator,
+			Second:   []Bind{{Field: "id", Value: 1}, {Field: "id", Value: 2}},
+		},
+		Second: Where{
+			First:    "id",
+			Operator: InOperator,
+			Second:   []Bind{{Field: "id", Value: 3}},
+			Type:     WhereOrType,
+		},
+	}, In("id", []int{1, 2, 3}))
+
+	assert.Equal(t, Where{
+		First: Where{
+			First:    "id",
+			Operator: NotInOperator,
+			Second:   []Bind{{Field: "id", Value: 1}, {Field: "id", Value: 2}},
+		},
+		Second: Where{
+			First:    "id",
+			Operator: NotInOperator,
+			Second:   []Bind{{Field: "id", Value: 3}, {Field: "id", Value: 4}},
+			Type:     WhereAndType,
+		},
+	}, NotIn("id", []int{1, 2, 3, 4}))
+}
@@ -0,0 +1,61 @@
+package query
+
+import "fmt"
+
+// Window the window definition, which can be used in the OVER clause of the window function or in the WINDOW clause of the query.
+// If the Name is specified in the OVER clause, the named window from the WINDOW clause will be used as base window
+type Window struct {
+	Name        string
+	PartitionBy []string
+	OrderBy     []OrderByColumn
+	Frame       string
+}
+
+// WindowFunction the window function, which can be used in the select columns list. Eg: ROW_NUMBER() OVER (PARTITION BY type ORDER BY id DESC) AS row_num
+type WindowFunction struct {
+	Function string
+	Window   Window
+	Alias    string
+}
+
+// Over sets the window of the window function
+func (f WindowFunction) Over(window Window) WindowFunction {
+	f.Window = window
+	return f
+}
+
+// As sets the alias of the window function column
+func (f WindowFunction) As(alias string) WindowFunction {
+	f.Alias = alias
+	return f
+}
+
+// RowNumber the ROW_NUMBER() window function
+func RowNumber() WindowFunction {
+	return WindowFunction{Function: "ROW_NUMBER()"}
+}
+
+// Rank the RANK() window function
+func Rank() WindowFunction {
+	return WindowFunction{Function: "RANK()"}
+}
+
+// DenseRank the DENSE_RANK() window function
+func DenseRank() WindowFunction {
+	return WindowFunction{Function: "DENSE_RANK()"}
+}
+
+// Lag the LAG(column, offset) window function, which returns the value of the column from the previous rows
+func Lag(column string, offset int64) WindowFunction {
+	return WindowFunction{Function: fmt.Sprintf("LAG(%s, %d)", column, offset)}
+}
+
+// Lead the LEAD(column, offset) window function, which returns the value of the column from the next rows
+func Lead(column string, offset int64) WindowFunction {
+	return WindowFunction{Function: fmt.Sprintf("LEAD(%s, %d)", column, offset)}
+}
+
+// Sum the SUM(column) aggregate function. In the combination with the window ORDER BY it returns the running total
+func Sum(column string) WindowFunction {
+	return WindowFunction{Function: fmt.Sprintf("SUM(%s)", column)}
+}